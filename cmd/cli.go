@@ -3,18 +3,25 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/arhamj/abi-extractor/pkg/asm"
+	"github.com/arhamj/abi-extractor/pkg/classifier"
 	"github.com/arhamj/abi-extractor/pkg/external"
 	"github.com/arhamj/abi-extractor/pkg/scraper"
 	"github.com/arhamj/abi-extractor/pkg/service"
 	"github.com/arhamj/abi-extractor/pkg/util"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 )
 
 var (
@@ -30,22 +37,144 @@ var (
 		Usage:    "Provide a custom RPC endpoint",
 		Required: false,
 	}
+	// OptionalContractAddressFlag provides the contract address for commands which also accept raw bytecode
+	OptionalContractAddressFlag = &cli.StringFlag{
+		Name:     "contract",
+		Usage:    "Provide the contract address (or use --bytecode)",
+		Required: false,
+	}
+	// BytecodeFlag provides the runtime bytecode in hex instead of fetching it from the node
+	BytecodeFlag = &cli.StringFlag{
+		Name:     "bytecode",
+		Usage:    "Provide the contract bytecode in hex (or use --contract)",
+		Required: false,
+	}
+	// CfgFormatFlag provides the output format of the control flow graph
+	CfgFormatFlag = &cli.StringFlag{
+		Name:     "format",
+		Usage:    "Provide the output format: dot or mermaid",
+		Value:    "dot",
+		Required: false,
+	}
+	// SelectorFlag restricts the output to a single function
+	SelectorFlag = &cli.StringFlag{
+		Name:     "selector",
+		Usage:    "Provide the function selector, for e.g. 0xa9059cbb",
+		Required: false,
+	}
+	// OutputFileFlag provides the file to write the output to
+	OutputFileFlag = &cli.StringFlag{
+		Name:     "output",
+		Usage:    "Provide the output file (default: stdout)",
+		Required: false,
+	}
+	// CompilerFlag overrides the compiler detected from the bytecode
+	CompilerFlag = &cli.StringFlag{
+		Name:     "compiler",
+		Usage:    "Provide the compiler of the contract: auto, solidity or vyper",
+		Value:    string(asm.AutoDetect),
+		Required: false,
+	}
 	// HexStringFlag provides a custom RPC endpoint
 	HexStringFlag = &cli.StringFlag{
 		Name:     "hex",
 		Usage:    "Provide the hex string flag to be decoded",
 		Required: true,
 	}
+	// TxHashFlag provides the transaction hash
+	TxHashFlag = &cli.StringFlag{
+		Name:     "tx",
+		Usage:    "Provide the transaction hash",
+		Required: true,
+	}
+	// JSONFlag switches the output to JSON
+	JSONFlag = &cli.BoolFlag{
+		Name:     "json",
+		Usage:    "Print the output as JSON",
+		Required: false,
+	}
+	// AllCandidatesFlag lists every candidate text signature of a selector instead of the best one
+	AllCandidatesFlag = &cli.BoolFlag{
+		Name:     "all-candidates",
+		Usage:    "Print every candidate text signature of each selector, ranked, to spot collisions",
+		Required: false,
+	}
+	// SampleFlag provides calldata observed for a selector
+	SampleFlag = &cli.StringSliceFlag{
+		Name:     "sample",
+		Usage:    "Provide calldata of the function in hex, the candidates which do not decode every sample are discarded",
+		Required: false,
+	}
+	// TopicsFlag provides the topics of a log
+	TopicsFlag = &cli.StringSliceFlag{
+		Name:     "topics",
+		Usage:    "Provide the topics of the log in hex, the event signature first (or use --log)",
+		Required: false,
+	}
+	// DataFlag provides the data of a log
+	DataFlag = &cli.StringFlag{
+		Name:     "data",
+		Usage:    "Provide the data of the log in hex",
+		Required: false,
+	}
+	// LogFlag provides a log object of a transaction receipt
+	LogFlag = &cli.StringFlag{
+		Name:     "log",
+		Usage:    "Provide the log as the JSON object found in the logs of a transaction receipt (or use --topics)",
+		Required: false,
+	}
 )
 
+// remoteSourceTimeout bounds a single lookup of a remote signature source
+const remoteSourceTimeout = 10 * time.Second
+
 var (
 	defaultFlags = []cli.Flag{
 		ContractAddressFlag,
 		NodeRpcEndpointFlag,
+		CompilerFlag,
+	}
+	textFunctionFlags = []cli.Flag{
+		ContractAddressFlag,
+		NodeRpcEndpointFlag,
+		CompilerFlag,
+		AllCandidatesFlag,
 	}
 	hexFlags = []cli.Flag{
 		HexStringFlag,
 	}
+	hexFunctionFlags = []cli.Flag{
+		HexStringFlag,
+		SampleFlag,
+	}
+	logFlags = []cli.Flag{
+		TopicsFlag,
+		DataFlag,
+		LogFlag,
+	}
+	txFlags = []cli.Flag{
+		TxHashFlag,
+		NodeRpcEndpointFlag,
+	}
+	traceFlags = []cli.Flag{
+		TxHashFlag,
+		NodeRpcEndpointFlag,
+		JSONFlag,
+	}
+	cfgFlags = []cli.Flag{
+		OptionalContractAddressFlag,
+		BytecodeFlag,
+		NodeRpcEndpointFlag,
+		CfgFormatFlag,
+		SelectorFlag,
+		OutputFileFlag,
+		CompilerFlag,
+	}
+	bytecodeFlags = []cli.Flag{
+		OptionalContractAddressFlag,
+		BytecodeFlag,
+		NodeRpcEndpointFlag,
+	}
 )
 
 type app struct {
@@ -54,12 +183,14 @@ type app struct {
 
 	scraperDb *sql.DB
 
+	disassembler   asm.Disassembler
 	bytecodeParser asm.BytecodeParser
 
 	bytecodeService service.BytecodeService
 	signDecoder     service.SignDecoderService
 
 	bytecode *external.EthCodeResp
+	compiler asm.Compiler
 }
 
 func main() {
@@ -106,9 +237,52 @@ func main() {
 				Name:        "text-functions",
 				Aliases:     []string{"tf"},
 				Description: "extract the function signature (in text) from contract bytecode",
-				Flags:       defaultFlags,
+				Flags:       textFunctionFlags,
 				Action:      a.PrintDecodedFunctionsSignatures,
 			},
+			{
+				Name:        "text-errors",
+				Aliases:     []string{"terr"},
+				Description: "extract the custom error signature (in text) from contract bytecode",
+				Flags:       defaultFlags,
+				Action:      a.PrintDecodedErrorSignatures,
+			},
+			{
+				Name:        "abi",
+				Description: "generate the JSON ABI from contract bytecode",
+				Flags:       defaultFlags,
+				Action:      a.PrintABI,
+			},
+			{
+				Name:        "classify",
+				Description: "classify the contract into ERC standards from its function and event signatures",
+				Flags:       bytecodeFlags,
+				Action:      a.PrintClassification,
+			},
+			{
+				Name:        "interfaces",
+				Description: "extract the ERC-165 interface IDs compared in supportsInterface and name the well-known ones",
+				Flags:       bytecodeFlags,
+				Action:      a.PrintInterfaces,
+			},
+			{
+				Name:        "cfg",
+				Description: "export the control flow graph of the contract or of a single function as DOT or Mermaid",
+				Flags:       cfgFlags,
+				Action:      a.PrintCFG,
+			},
+			{
+				Name:        "proxy",
+				Description: "detect a proxy, resolve its implementation and generate the JSON ABI of both, or list the facets of a diamond",
+				Flags:       defaultFlags,
+				Action:      a.PrintProxy,
+			},
+			{
+				Name:        "metadata",
+				Description: "decode the compiler version and the source hash from the metadata trailer of the bytecode",
+				Flags:       bytecodeFlags,
+				Action:      a.PrintMetadata,
+			},
 			{
 				Name:        "decode-hex-event",
 				Aliases:     []string{"dhe"},
@@ -120,9 +294,36 @@ func main() {
 				Name:        "decode-hex-function",
 				Aliases:     []string{"dhf"},
 				Description: "extract the text signature of a given hex function",
-				Flags:       hexFlags,
+				Flags:       hexFunctionFlags,
 				Action:      a.PrintDecodedFunctionSignature,
 			},
+			{
+				Name:        "decode-calldata",
+				Aliases:     []string{"dc"},
+				Description: "decode the function and the arguments of raw transaction input",
+				Flags:       hexFlags,
+				Action:      a.PrintDecodedCalldata,
+			},
+			{
+				Name:        "decode-log",
+				Aliases:     []string{"dl"},
+				Description: "decode the event and the parameters of a log from its topics and data",
+				Flags:       logFlags,
+				Action:      a.PrintDecodedLog,
+			},
+			{
+				Name:        "decode-tx",
+				Aliases:     []string{"dtx"},
+				Description: "fetch a transaction and its receipt and decode the calldata and every emitted log",
+				Flags:       txFlags,
+				Action:      a.PrintDecodedTransaction,
+			},
+			{
+				Name:        "trace",
+				Description: "trace a transaction with debug_traceTransaction and decode every call frame, the node needs the debug namespace",
+				Flags:       traceFlags,
+				Action:      a.PrintTrace,
+			},
 			{
 				Name:        "sync-4byte-events",
 				Aliases:     []string{"s4e"},
@@ -145,42 +346,105 @@ func main() {
 }
 
 func (a *app) setupApp(c *cli.Context) error {
-	contract := c.String(ContractAddressFlag.Name)
-	a.chainGateway = external.NewChainGatewayWithOpts()
-	if NodeRpcEndpointFlag.IsSet() {
-		endpoint := c.String(NodeRpcEndpointFlag.Name)
-		a.chainGateway = external.NewChainGatewayWithOpts(external.WithEthEndpoint(endpoint))
-	}
-	resp, err := a.chainGateway.EthGetCode(contract)
+	a.setupChainGateway(c)
+	resp, err := a.fetchBytecode(c)
 	if err != nil {
 		return err
 	}
-	parser, err := asm.NewSolidityParserStr(resp.Result[2:])
+	compiler := asm.AutoDetect
+	if c.IsSet(CompilerFlag.Name) {
+		compiler, err = asm.ParseCompiler(c.String(CompilerFlag.Name))
+		if err != nil {
+			return err
+		}
+	}
+	a.logger = zap.L()
+	a.disassembler, a.bytecodeParser, err = a.parseBytecode(resp.Result, compiler)
 	if err != nil {
 		return err
 	}
-	a.bytecodeParser = parser
+	a.compiler = compiler
 	a.bytecode = resp
-	a.logger = zap.L()
-	scraperDb, err := util.NewSQLiteDB("db/scraper.db", scraper.FourByteMigrations)
+	scraperDb, err := util.NewSQLiteDB("db/scraper.db", scraper.FourByteMigrations, service.SignCacheMigrations)
 	if err != nil {
 		return err
 	}
 	a.scraperDb = scraperDb
-	a.signDecoder = service.NewSignDecoder(external.NewSamczsunGateway(), service.WithScraperDbOpt(scraperDb))
-	a.bytecodeService = service.NewBytecodeService(a.signDecoder)
+	a.setupSignDecoder()
 	return nil
 }
 
+// setupSignDecoder resolves signatures from the scraper db, then samczsun and then 4byte. The lookups of the remote
+// sources are cached in the scraper db
+func (a *app) setupSignDecoder() {
+	samczsun := service.NewSamczsunSource(external.NewSamczsunGateway())
+	fourByte := service.NewFourByteSource(external.NewFourByteGateway())
+	sources := service.NewSourceChain(
+		service.WithSource(service.NewSQLiteSource(a.scraperDb), 0),
+		service.WithSource(service.NewCachedSource(samczsun, service.WithCacheDb(a.scraperDb)), remoteSourceTimeout),
+		service.WithSource(service.NewCachedSource(fourByte, service.WithCacheDb(a.scraperDb)), remoteSourceTimeout),
+	)
+	a.signDecoder = service.NewSignDecoder(external.NewSamczsunGateway(), service.WithSourceChain(sources))
+	a.bytecodeService = service.NewBytecodeService(a.signDecoder)
+}
+
+// setupChainGateway connects to the node passed with --node or to the default endpoint
+func (a *app) setupChainGateway(c *cli.Context) {
+	a.chainGateway = external.NewChainGatewayWithOpts()
+	if c.IsSet(NodeRpcEndpointFlag.Name) {
+		endpoint := c.String(NodeRpcEndpointFlag.Name)
+		a.chainGateway = external.NewChainGatewayWithOpts(external.WithEthEndpoint(endpoint))
+	}
+}
+
+// parseBytecode disassembles the hex bytecode, using the runtime section of creation code, and selects the parser
+func (a *app) parseBytecode(hexCode string, compiler asm.Compiler) (asm.Disassembler, asm.BytecodeParser, error) {
+	code, err := hex.DecodeString(strings.TrimPrefix(hexCode, "0x"))
+	if err != nil {
+		return asm.Disassembler{}, nil, err
+	}
+	if creation, err := asm.SplitCreationCode(code); err == nil {
+		a.logger.Info("using the runtime section of creation code", zap.Uint64("offset", creation.RuntimeOffset),
+			zap.Int("constructorArgs", len(creation.ConstructorArgs)))
+		code = creation.Runtime
+	}
+	d, err := asm.NewDisassembler(code)
+	if err != nil {
+		return asm.Disassembler{}, nil, err
+	}
+	parser, detection := asm.NewBytecodeParser(d, compiler)
+	a.logger.Info("selected bytecode parser", zap.String("compiler", string(detection.Compiler)),
+		zap.Float64("confidence", detection.Confidence), zap.Strings("evidence", detection.Evidence))
+	return d, parser, nil
+}
+
+// fetchBytecode returns the bytecode passed with --bytecode or fetches it for --contract from the node
+func (a *app) fetchBytecode(c *cli.Context) (*external.EthCodeResp, error) {
+	if c.IsSet(BytecodeFlag.Name) {
+		return &external.EthCodeResp{Result: "0x" + strings.TrimPrefix(c.String(BytecodeFlag.Name), "0x")}, nil
+	}
+	contract := c.String(ContractAddressFlag.Name)
+	if contract == "" {
+		return nil, errors.New("either --contract or --bytecode must be provided")
+	}
+	resp, err := a.chainGateway.EthGetCode(contract)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Result) < 2 {
+		return nil, errors.New("invalid bytecode returned by node")
+	}
+	return resp, nil
+}
+
 func (a *app) setupAppWithoutContract(c *cli.Context) error {
 	a.logger = zap.L()
-	scraperDb, err := util.NewSQLiteDB("db/scraper.db", scraper.FourByteMigrations)
+	scraperDb, err := util.NewSQLiteDB("db/scraper.db", scraper.FourByteMigrations, service.SignCacheMigrations)
 	if err != nil {
 		return err
 	}
 	a.scraperDb = scraperDb
-	a.signDecoder = service.NewSignDecoder(external.NewSamczsunGateway(), service.WithScraperDbOpt(scraperDb))
-	a.bytecodeService = service.NewBytecodeService(a.signDecoder)
+	a.setupSignDecoder()
 	return nil
 }
 
@@ -238,11 +502,281 @@ func (a *app) PrintDecodedFunctionsSignatures(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool(AllCandidatesFlag.Name) {
+		a.printFunctionCandidates()
+		return nil
+	}
 	res := a.bytecodeService.GetDecodedFunctionSigns(a.bytecodeParser)
 	fmt.Println("\nFunction signatures (<in hex>: <in text>):")
 	for hexSign, textSign := range res {
 		fmt.Printf("- %s: %s\n", hexSign, textSign)
 	}
+	inferred := a.bytecodeService.InferFunctionSigns(a.bytecodeParser.GetFunctionSigns().List(),
+		a.bytecodeParser.GetFunctions(), res)
+	if len(inferred) == 0 {
+		return nil
+	}
+	fmt.Println("\nInferred function signatures, unverified (<in hex>: <in text>):")
+	for hexSign, textSign := range inferred {
+		fmt.Printf("- %s: %s\n", hexSign, textSign)
+	}
+	return nil
+}
+
+// printFunctionCandidates prints the ranked candidates of every selector, selectors with more than one are collisions
+func (a *app) printFunctionCandidates() {
+	fmt.Println("\nFunction signatures (<in hex>: [score] <in text> <sources> <created at>):")
+	for _, hexSign := range a.bytecodeService.GetFunctionSigns(a.bytecodeParser).List() {
+		candidates, err := a.signDecoder.ResolveAll(service.FunctionSignature, hexSign)
+		if err != nil {
+			fmt.Printf("- %s: lookup failed, %v\n", hexSign, err)
+			continue
+		}
+		switch len(candidates) {
+		case 0:
+			fmt.Printf("- %s: unknown\n", hexSign)
+			continue
+		case 1:
+			fmt.Printf("- %s:\n", hexSign)
+		default:
+			fmt.Printf("- %s: collision, %d candidates\n", hexSign, len(candidates))
+		}
+		for _, candidate := range candidates {
+			line := fmt.Sprintf("    [%d] %s (%s)", candidate.Score, candidate.Sign, strings.Join(candidate.Sources, ", "))
+			if !candidate.CreatedAt.IsZero() {
+				line += " " + candidate.CreatedAt.Format(time.RFC3339)
+			}
+			if candidate.Spam {
+				line += " spam"
+			}
+			if !candidate.Decoded {
+				line += " hash mismatch"
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+func (a *app) PrintDecodedErrorSignatures(c *cli.Context) error {
+	err := a.setupApp(c)
+	if err != nil {
+		return err
+	}
+	res := a.bytecodeService.GetDecodedErrorSigns(a.bytecodeParser)
+	fmt.Println("\nError signatures (<in hex>: <in text>):")
+	for _, hexSign := range a.bytecodeService.GetErrorSigns(a.bytecodeParser).List() {
+		textSign, ok := res[hexSign]
+		if !ok {
+			textSign = "unknown"
+		}
+		fmt.Printf("- %s: %s\n", hexSign, textSign)
+	}
+	return nil
+}
+
+func (a *app) PrintABI(c *cli.Context) error {
+	err := a.setupApp(c)
+	if err != nil {
+		return err
+	}
+	fmt.Println(a.bytecodeService.GetABI(a.bytecodeParser))
+	return nil
+}
+
+func (a *app) PrintCFG(c *cli.Context) error {
+	err := a.setupApp(c)
+	if err != nil {
+		return err
+	}
+	cfg := asm.NewCFG(a.disassembler)
+	emulator := asm.NewEmulator(a.disassembler)
+	blocks := cfg.SortedBlocks()
+	if c.IsSet(SelectorFlag.Name) {
+		selector := strings.ToLower(c.String(SelectorFlag.Name))
+		entry, ok := a.bytecodeParser.GetFunctionEntries()[selector]
+		if !ok {
+			return fmt.Errorf("function selector %s not found in the dispatcher", selector)
+		}
+		cfg.ResolveDynamicJumpsFrom(emulator, entry)
+		blocks = cfg.Reachable(entry)
+	} else {
+		cfg.ResolveDynamicJumps(emulator)
+	}
+
+	var out string
+	switch c.String(CfgFormatFlag.Name) {
+	case "dot":
+		out = cfg.DOT(blocks)
+	case "mermaid":
+		out = cfg.Mermaid(blocks)
+	default:
+		return fmt.Errorf("unsupported format %s", c.String(CfgFormatFlag.Name))
+	}
+	if c.IsSet(OutputFileFlag.Name) {
+		return os.WriteFile(c.String(OutputFileFlag.Name), []byte(out), 0644)
+	}
+	fmt.Print(out)
+	return nil
+}
+
+func (a *app) PrintProxy(c *cli.Context) error {
+	err := a.setupApp(c)
+	if err != nil {
+		return err
+	}
+	proxy, ok := asm.DetectProxy(a.disassembler)
+	if !ok {
+		return errors.New("the contract is not a recognised proxy")
+	}
+	if proxy.Kind == asm.DiamondProxy {
+		return a.printFacets(c)
+	}
+	implementation := proxy.Implementation
+	if proxy.HasSlot() {
+		resp, err := a.chainGateway.EthGetStorageAt(c.String(ContractAddressFlag.Name), proxy.Slot)
+		if err != nil {
+			return err
+		}
+		implementation = common.HexToAddress(resp.Result).Hex()
+	}
+	if common.HexToAddress(implementation) == (common.Address{}) {
+		return fmt.Errorf("the implementation slot %s of the %s proxy is empty", proxy.Slot, proxy.Kind)
+	}
+	resp, err := a.chainGateway.EthGetCode(implementation)
+	if err != nil {
+		return err
+	}
+	_, implementationParser, err := a.parseBytecode(resp.Result, a.compiler)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nProxy: %s\n", proxy.Kind)
+	fmt.Printf("Implementation: %s\n", implementation)
+	fmt.Println("\nProxy ABI:")
+	fmt.Println(a.bytecodeService.GetABI(a.bytecodeParser))
+	fmt.Println("\nImplementation ABI:")
+	fmt.Println(a.bytecodeService.GetABI(implementationParser))
+	return nil
+}
+
+// printFacets lists the facets of a diamond with facets() and parses the bytecode of each of them
+func (a *app) printFacets(c *cli.Context) error {
+	resp, err := a.chainGateway.EthCall(c.String(ContractAddressFlag.Name), service.FacetsSelector)
+	if err != nil {
+		return err
+	}
+	facets, err := service.DecodeFacets(resp.Result)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nProxy: %s\n", asm.DiamondProxy)
+	for _, facet := range facets {
+		codeResp, err := a.chainGateway.EthGetCode(facet.Address)
+		if err != nil {
+			return err
+		}
+		_, facetParser, err := a.parseBytecode(codeResp.Result, a.compiler)
+		if err != nil {
+			return err
+		}
+		report := a.bytecodeService.GetFacetReport(facet, facetParser)
+		fmt.Printf("\nFacet: %s\n", report.Address)
+		for _, selector := range report.Selectors {
+			textSign, ok := report.DecodedSigns[selector]
+			if !ok {
+				textSign = "unknown"
+			}
+			fmt.Printf("%s: %s\n", selector, textSign)
+		}
+		if len(report.NotFound) > 0 {
+			fmt.Println("Routed but not found in the facet bytecode:", report.NotFound)
+		}
+		if len(report.NotRouted) > 0 {
+			fmt.Println("Found in the facet bytecode but not routed:", report.NotRouted)
+		}
+	}
+	return nil
+}
+
+func (a *app) PrintClassification(c *cli.Context) error {
+	err := a.setupApp(c)
+	if err != nil {
+		return err
+	}
+	cls := classifier.NewClassifier()
+	matches := cls.Classify(a.bytecodeService.GetFunctionSigns(a.bytecodeParser),
+		a.bytecodeService.GetEventSigns(a.bytecodeParser))
+	if len(matches) == 0 {
+		fmt.Println("\nNo standard matched")
+	} else {
+		fmt.Println("\nStandards:")
+	}
+	for _, match := range matches {
+		kind := "partial"
+		if match.Full {
+			kind = "full"
+		}
+		fmt.Printf("- %s: %s match (%d/%d)\n", match.Standard, kind, match.Matched, match.Total)
+		for _, textSign := range match.MissingFunctions {
+			fmt.Printf("  missing function %s\n", textSign)
+		}
+		for _, textSign := range match.MissingEvents {
+			fmt.Printf("  missing event %s\n", textSign)
+		}
+	}
+	a.printInterfaces(cls)
+	return nil
+}
+
+func (a *app) PrintInterfaces(c *cli.Context) error {
+	err := a.setupApp(c)
+	if err != nil {
+		return err
+	}
+	if _, ok := a.bytecodeParser.GetFunctionEntries()[asm.SupportsInterfaceSelector]; !ok {
+		fmt.Println("\nsupportsInterface(bytes4) not found")
+		return nil
+	}
+	a.printInterfaces(classifier.NewClassifier())
+	return nil
+}
+
+// printInterfaces lists the interface IDs compared in supportsInterface, nothing when the function is not found
+func (a *app) printInterfaces(cls classifier.Classifier) {
+	ids := asm.InterfaceIDs(a.disassembler, a.bytecodeParser.GetFunctionEntries())
+	if len(ids) == 0 {
+		return
+	}
+	fmt.Println("\nSupported interfaces:")
+	for _, supported := range cls.Interfaces(ids) {
+		name := supported.Name
+		if name == "" {
+			name = "unknown"
+		}
+		fmt.Printf("- %s: %s\n", supported.ID, name)
+	}
+}
+
+func (a *app) PrintMetadata(c *cli.Context) error {
+	err := a.setupApp(c)
+	if err != nil {
+		return err
+	}
+	metadata := a.disassembler.Metadata
+	if metadata == nil {
+		return asm.ErrNoMetadata
+	}
+	fmt.Println("\nMetadata:")
+	fmt.Printf("- compiler: %s\n", metadata.Compiler)
+	if metadata.Version != "" {
+		fmt.Printf("- version: %s\n", metadata.Version)
+	}
+	if metadata.SourceURL() != "" {
+		fmt.Printf("- source: %s\n", metadata.SourceURL())
+	}
+	if metadata.Experimental {
+		fmt.Println("- experimental: true")
+	}
 	return nil
 }
 
@@ -266,6 +800,9 @@ func (a *app) PrintDecodedFunctionSignature(c *cli.Context) error {
 		return err
 	}
 	hexString := c.String(HexStringFlag.Name)
+	if c.IsSet(SampleFlag.Name) {
+		return a.printFunctionCandidatesWithSamples(hexString, c.StringSlice(SampleFlag.Name))
+	}
 	res, err := a.signDecoder.GetFunctionTextSignature(hexString)
 	if err != nil {
 		return err
@@ -274,6 +811,204 @@ func (a *app) PrintDecodedFunctionSignature(c *cli.Context) error {
 	return nil
 }
 
+// printFunctionCandidatesWithSamples prints the candidates of the selector which decode every sample calldata
+func (a *app) printFunctionCandidatesWithSamples(hexString string, samples []string) error {
+	data := make([][]byte, 0, len(samples))
+	for _, sample := range samples {
+		b, err := hex.DecodeString(strings.TrimPrefix(sample, "0x"))
+		if err != nil {
+			return err
+		}
+		data = append(data, b)
+	}
+	candidates, err := a.signDecoder.ResolveWithCalldata(hexString, data...)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("no text signature of %s decodes every sample", hexString)
+	}
+	fmt.Println("\nFunction signatures decoding every sample (<score>: <in text>):")
+	for _, candidate := range candidates {
+		fmt.Printf("- %d: %s\n", candidate.Score, candidate.Sign)
+	}
+	return nil
+}
+
+func (a *app) PrintDecodedCalldata(c *cli.Context) error {
+	err := a.setupAppWithoutContract(c)
+	if err != nil {
+		return err
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(c.String(HexStringFlag.Name), "0x"))
+	if err != nil {
+		return err
+	}
+	call, err := a.signDecoder.DecodeCalldata(data)
+	if err != nil {
+		return err
+	}
+	printDecodedCall(call)
+	return nil
+}
+
+func printDecodedCall(call *service.DecodedCall) {
+	fmt.Printf("\nFunction: %s\n", call.Signature.Sign)
+	if !call.Signature.Verified {
+		fmt.Println("Text signature is likely spam")
+	}
+	for _, arg := range call.Arguments {
+		fmt.Printf("- %s %s: %s\n", arg.Name, arg.Type, arg)
+	}
+}
+
+func (a *app) PrintDecodedLog(c *cli.Context) error {
+	err := a.setupAppWithoutContract(c)
+	if err != nil {
+		return err
+	}
+	ethLog := external.EthLog{Topics: c.StringSlice(TopicsFlag.Name), Data: c.String(DataFlag.Name)}
+	switch {
+	case c.IsSet(LogFlag.Name):
+		if err = json.Unmarshal([]byte(c.String(LogFlag.Name)), &ethLog); err != nil {
+			return err
+		}
+	case !c.IsSet(TopicsFlag.Name):
+		return errors.New("either --topics or --log must be provided")
+	}
+	if ethLog.Data == "" {
+		ethLog.Data = "0x"
+	}
+	decoded, err := a.signDecoder.DecodeEthLog(ethLog)
+	if err != nil {
+		return err
+	}
+	printDecodedLog(decoded)
+	return nil
+}
+
+func printDecodedLog(decoded *service.DecodedLog) {
+	fmt.Printf("\nEvent: %s\n", decoded.Signature.Sign)
+	if !decoded.Signature.Verified {
+		fmt.Println("Text signature is likely spam")
+	}
+	for _, arg := range decoded.Arguments {
+		indexed := ""
+		if arg.Indexed {
+			indexed = " indexed"
+		}
+		fmt.Printf("- %s %s%s: %s\n", arg.Name, arg.Type, indexed, arg)
+	}
+}
+
+func (a *app) PrintDecodedTransaction(c *cli.Context) error {
+	err := a.setupAppWithoutContract(c)
+	if err != nil {
+		return err
+	}
+	a.setupChainGateway(c)
+	hash := c.String(TxHashFlag.Name)
+	txResp, err := a.chainGateway.EthGetTransactionByHash(hash)
+	if err != nil {
+		return err
+	}
+	receiptResp, err := a.chainGateway.EthGetTransactionReceipt(hash)
+	if err != nil {
+		return err
+	}
+	if txResp.Result == nil || receiptResp.Result == nil {
+		return fmt.Errorf("transaction %s not found or pending", hash)
+	}
+	tx := a.signDecoder.DecodeTransaction(*txResp.Result, *receiptResp.Result)
+	fmt.Printf("\nTransaction: %s\n", tx.Hash)
+	fmt.Printf("- from: %s\n- to: %s\n- value: %s\n- status: %s\n", tx.From, tx.To, tx.Value, tx.Status)
+	switch {
+	case tx.Call != nil:
+		printDecodedCall(tx.Call)
+	case tx.To == "":
+		fmt.Println("\nContract creation")
+	default:
+		fmt.Printf("\nFunction: unknown\n- input: %s\n", tx.Input)
+	}
+	for i, txLog := range tx.Logs {
+		fmt.Printf("\nLog %d emitted by %s", i, txLog.Address)
+		if txLog.Decoded != nil {
+			printDecodedLog(txLog.Decoded)
+			continue
+		}
+		fmt.Printf("\nEvent: unknown\n- topics: %v\n- data: %s\n", txLog.Topics, txLog.Data)
+	}
+	return nil
+}
+
+func (a *app) PrintTrace(c *cli.Context) error {
+	err := a.setupAppWithoutContract(c)
+	if err != nil {
+		return err
+	}
+	a.setupChainGateway(c)
+	hash := c.String(TxHashFlag.Name)
+	resp, err := a.chainGateway.DebugTraceTransaction(hash)
+	if err != nil {
+		// e.g. the node does not expose the debug namespace
+		return fmt.Errorf("tracing transaction %s: %w", hash, err)
+	}
+	if resp.Result == nil {
+		return fmt.Errorf("transaction %s not found", hash)
+	}
+	trace := a.bytecodeService.DecodeTrace(a.chainGateway, *resp.Result)
+	if c.Bool(JSONFlag.Name) {
+		out, err := json.MarshalIndent(trace, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	fmt.Println()
+	printFrame(trace, 0)
+	return nil
+}
+
+// printFrame prints the frame and its nested calls indented by depth
+func printFrame(frame service.DecodedFrame, depth int) {
+	indent := strings.Repeat("  ", depth)
+	label := frame.Function
+	if label == "" {
+		label = "(" + frame.Input + ")"
+	}
+	fmt.Printf("%s%s %s %s\n", indent, frame.Type, frame.To, label)
+	if frame.Function != "" && !frame.Verified {
+		fmt.Printf("%s  text signature is unverified\n", indent)
+	}
+	for _, arg := range frame.Arguments {
+		fmt.Printf("%s  - %s %s: %s\n", indent, arg.Name, arg.Type, arg)
+	}
+	switch {
+	case frame.Error != "":
+		fmt.Printf("%s  reverted: %s\n", indent, frame.Error)
+	case len(frame.Outputs) > 0:
+		for _, output := range frame.Outputs {
+			fmt.Printf("%s  returns %s: %s\n", indent, output.Type, output)
+		}
+	case frame.Output != "" && frame.Output != "0x":
+		fmt.Printf("%s  returns %s\n", indent, frame.Output)
+	}
+	for _, txLog := range frame.Logs {
+		if txLog.Decoded == nil {
+			fmt.Printf("%s  emits unknown %v %s\n", indent, txLog.Topics, txLog.Data)
+			continue
+		}
+		fmt.Printf("%s  emits %s\n", indent, txLog.Decoded.Signature.Sign)
+		for _, arg := range txLog.Decoded.Arguments {
+			fmt.Printf("%s    - %s %s: %s\n", indent, arg.Name, arg.Type, arg)
+		}
+	}
+	for _, call := range frame.Calls {
+		printFrame(call, depth+1)
+	}
+}
+
 func (a *app) Sync4Byte(c *cli.Context, kind scraper.MappingKind) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	defer cancel()
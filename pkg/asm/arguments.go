@@ -0,0 +1,233 @@
+package asm
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+)
+
+const (
+	// argumentsMaxSteps bounds the emulation of a single function body
+	argumentsMaxSteps = 100_000
+	// argumentsMaxDepth bounds the search for the argument a value is derived from
+	argumentsMaxDepth = 8
+	// defaultArgumentType is used for the head words which are loaded without being cleaned up
+	defaultArgumentType = "uint256"
+)
+
+// Ranks of the evidence for the type of an argument, the strongest evidence wins
+const (
+	rankDefault = iota
+	rankMask
+	rankSignExtend
+	rankBool
+	rankBytes
+	rankArray
+)
+
+var big1 = big.NewInt(1)
+
+// argumentTypes collects the strongest evidence for the type of each argument by its position in the head
+type argumentTypes struct {
+	types map[int]string
+	ranks map[int]int
+	last  int
+	// negations are the PCs of ISZERO(ISZERO(<head>)), which is a bool cleanup unless it is a jump condition
+	negations  map[uint64]int
+	conditions map[uint64]bool
+}
+
+func (a *argumentTypes) set(index int, typ string, rank int) {
+	if current, ok := a.ranks[index]; ok && current >= rank {
+		return
+	}
+	a.types[index], a.ranks[index] = typ, rank
+	if index > a.last {
+		a.last = index
+	}
+}
+
+// list returns the types in order, the head words which are never loaded are assumed to be uint256
+func (a *argumentTypes) list() []string {
+	res := make([]string, 0, a.last+1)
+	for i := 0; i <= a.last; i++ {
+		typ, ok := a.types[i]
+		if !ok {
+			typ = defaultArgumentType
+		}
+		res = append(res, typ)
+	}
+	return res
+}
+
+// InferArguments emulates the function body starting at entry and guesses the argument types from the way the
+// ABI encoded head words CALLDATALOAD(4 + 32*i) are cleaned up and validated
+//
+//	AND <2^160-1>                     address
+//	AND <2^n-1>, SHR n, LT 2^n        uint<n>
+//	AND <0xff..00>                    bytes<n>
+//	SIGNEXTEND <b>                    int<8*(b+1)>
+//	ISZERO ISZERO, LT 2               bool
+//	CALLDATALOAD(4 + <head>)          bytes, the head is an offset to the length followed by the data
+//	MUL 0x20 or SHL 5 of the length   uint256[]
+//
+// bytes and string, and the static types which compile to the same code, for e.g. uint160 and address, cannot be
+// told apart. The result is a guess and should be reported as unverified
+//
+//	Ref: https://docs.soliditylang.org/en/latest/abi-spec.html#formal-specification-of-the-encoding
+func InferArguments(d Disassembler, entry uint64) []string {
+	args := &argumentTypes{types: make(map[int]string), ranks: make(map[int]int), last: -1,
+		negations: make(map[uint64]int), conditions: make(map[uint64]bool)}
+	NewEmulator(d, WithMaxSteps(argumentsMaxSteps)).RunFrom(entry, nil, func(inst Instruction, state *ExecutionState) bool {
+		switch inst.Op {
+		case vm.AND:
+			for i := 0; i < 2; i++ {
+				if index, ok := headIndex(state.Peek(i)); ok {
+					if typ, ok := maskType(state.Peek(1 - i)); ok {
+						args.set(index, typ, rankMask)
+					}
+				}
+			}
+		case vm.SHR:
+			// SHR(n, <value>) is zero for the values of n bits
+			if index, ok := headIndex(state.Peek(1)); ok {
+				if bits, ok := byteWidth(state.Peek(0)); ok {
+					args.set(index, widthType(bits), rankMask)
+				}
+			}
+		case vm.LT:
+			if index, ok := headIndex(state.Peek(0)); ok && state.Peek(1).IsKnown() {
+				if bits, ok := powerOfTwo(state.Peek(1).Value); ok && bits == 1 {
+					args.set(index, "bool", rankBool)
+				} else if ok && bits%8 == 0 && bits < 256 {
+					args.set(index, widthType(uint64(bits)), rankMask)
+				}
+			}
+		case vm.SIGNEXTEND:
+			if index, ok := headIndex(state.Peek(1)); ok && state.Peek(0).IsKnown() && state.Peek(0).Value.Cmp(big.NewInt(31)) < 0 {
+				args.set(index, fmt.Sprintf("int%d", 8*(state.Peek(0).Value.Uint64()+1)), rankSignExtend)
+			}
+		case vm.ISZERO:
+			if v := state.Peek(0); !v.IsKnown() && v.Op == vm.ISZERO && len(v.Args) == 1 {
+				if index, ok := headIndex(v.Args[0]); ok {
+					args.negations[inst.PC] = index
+				}
+			}
+		case vm.JUMPI:
+			// if (<value> == 0) compiles to the same double negation
+			args.conditions[state.Peek(1).PC] = true
+		case vm.CALLDATALOAD:
+			if index, ok := headOffsetIndex(state.Peek(0)); ok {
+				args.set(index, defaultArgumentType, rankDefault)
+			} else if index, ok := dynamicIndex(state.Peek(0), 0); ok {
+				// The length of a dynamic argument is read at an offset derived from its head
+				args.set(index, "bytes", rankBytes)
+			}
+		case vm.MUL:
+			for i := 0; i < 2; i++ {
+				if index, ok := lengthIndex(state.Peek(i)); ok && state.Peek(1-i).IsConst(32) {
+					args.set(index, "uint256[]", rankArray)
+				}
+			}
+		case vm.SHL:
+			if index, ok := lengthIndex(state.Peek(1)); ok && state.Peek(0).IsConst(5) {
+				args.set(index, "uint256[]", rankArray)
+			}
+		}
+		return true
+	})
+	for pc, index := range args.negations {
+		if !args.conditions[pc] {
+			args.set(index, "bool", rankBool)
+		}
+	}
+	return args.list()
+}
+
+// headIndex returns the position of the argument when v is CALLDATALOAD(4 + 32*i)
+func headIndex(v StackValue) (int, bool) {
+	if v.IsKnown() || v.Op != vm.CALLDATALOAD || len(v.Args) != 1 {
+		return 0, false
+	}
+	return headOffsetIndex(v.Args[0])
+}
+
+// headOffsetIndex returns the position of the argument when offset is 4 + 32*i
+func headOffsetIndex(offset StackValue) (int, bool) {
+	if !offset.IsKnown() || !offset.Value.IsUint64() || offset.Value.Uint64() < 4 || (offset.Value.Uint64()-4)%32 != 0 {
+		return 0, false
+	}
+	return int((offset.Value.Uint64() - 4) / 32), true
+}
+
+// dynamicIndex returns the position of the argument whose head is part of the computation of v
+func dynamicIndex(v StackValue, depth int) (int, bool) {
+	if v.IsKnown() || depth > argumentsMaxDepth {
+		return 0, false
+	}
+	if index, ok := headIndex(v); ok {
+		return index, depth > 0
+	}
+	if v.Op != vm.ADD && v.Op != vm.SUB && v.Op != vm.AND {
+		return 0, false
+	}
+	for _, arg := range v.Args {
+		if index, ok := dynamicIndex(arg, depth+1); ok {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+// lengthIndex returns the position of the dynamic argument when v is its length
+func lengthIndex(v StackValue) (int, bool) {
+	if v.IsKnown() || v.Op != vm.CALLDATALOAD || len(v.Args) != 1 {
+		return 0, false
+	}
+	return dynamicIndex(v.Args[0], 0)
+}
+
+// maskType returns the type cleaned up by the AND mask, ones in the low bytes for uint<n> and address and ones in the
+// high bytes for bytes<n>
+func maskType(mask StackValue) (string, bool) {
+	if !mask.IsKnown() || mask.Value.Sign() == 0 {
+		return "", false
+	}
+	if bits, ok := powerOfTwo(new(big.Int).Add(mask.Value, big1)); ok && bits%8 == 0 && bits < 256 {
+		return widthType(uint64(bits)), true
+	}
+	// bytes<n> masks are the ones complement of a low mask
+	low := new(big.Int).Xor(mask.Value, tt256m1)
+	if bits, ok := powerOfTwo(new(big.Int).Add(low, big1)); ok && bits%8 == 0 && bits > 0 {
+		return fmt.Sprintf("bytes%d", 32-bits/8), true
+	}
+	return "", false
+}
+
+// widthType returns the unsigned type of the given bit width, 160 bits being an address
+func widthType(bits uint64) string {
+	if bits == 160 {
+		return "address"
+	}
+	return fmt.Sprintf("uint%d", bits)
+}
+
+// byteWidth returns the value as a number of bits when it is a multiple of 8 less than 256
+func byteWidth(v StackValue) (uint64, bool) {
+	if !v.IsKnown() || !v.Value.IsUint64() || v.Value.Uint64() == 0 || v.Value.Uint64() >= 256 || v.Value.Uint64()%8 != 0 {
+		return 0, false
+	}
+	return v.Value.Uint64(), true
+}
+
+// powerOfTwo returns n when v is 2^n
+func powerOfTwo(v *big.Int) (int, bool) {
+	if v.Sign() <= 0 {
+		return 0, false
+	}
+	n := v.BitLen() - 1
+	if new(big.Int).Lsh(big1, uint(n)).Cmp(v) != 0 {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,77 @@
+package asm
+
+import (
+	"reflect"
+	"testing"
+)
+
+// argumentsBytecode is a hand assembled function body reading 10 arguments
+//
+//	0  bool       ISZERO ISZERO, EQ with the value
+//	1  int8       SIGNEXTEND 0
+//	2  bytes4     AND 0xffffffff00..
+//	3  bytes      CALLDATALOAD(4 + <head>)
+//	4  uint256[]  MUL 0x20 of the length
+//	5  uint8      SHR 8
+//	6  bool       LT 2
+//	7  uint256    never loaded
+//	8  uint256    loaded only
+//	9  uint256    ISZERO ISZERO as a jump condition
+const argumentsBytecode = "6004358015158114600e575f80fd5b5060243560000b506044357fffffffff000000000000000000000000000000000000000000000000" +
+	"0000000016506064356004013550608435600401356020025060a43560081c5060c4356002811050506101043550610124351515606e57" +
+	"5b00"
+
+func TestInferArguments(t *testing.T) {
+	d := NewTestDisassembler(argumentsBytecode)
+	want := []string{"bool", "int8", "bytes4", "bytes", "uint256[]", "uint8", "bool", "uint256", "uint256", "uint256"}
+	if got := InferArguments(d, 0); !reflect.DeepEqual(got, want) {
+		t.Errorf("InferArguments() got = %v, want %v", got, want)
+	}
+}
+
+func TestSolidityParser_GetFunctions_Arguments(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want map[string][]string
+	}{
+		{
+			name: "Solidity 0.8 - Simple Token 2",
+			code: simpleTokenBytecode2,
+			want: map[string][]string{
+				"0x06fdde03": {},
+				"0x23b872dd": {"address", "address", "uint256"},
+				"0x70a08231": {"address"},
+				"0xa9059cbb": {"address", "uint256"},
+				"0xdd62ed3e": {"address", "address"},
+				// permit(address,address,uint256,uint256,uint8,bytes32,bytes32), bytes32 is not cleaned up
+				"0xd505accf": {"address", "address", "uint256", "uint256", "uint8", "uint256", "uint256"},
+			},
+		},
+		{
+			name: "Solidity 0.4 - USDT",
+			code: usdTBytecode,
+			want: map[string][]string{
+				"0x23b872dd": {"address", "address", "uint256"},
+				"0xa9059cbb": {"address", "uint256"},
+				"0xc0324c77": {"uint256", "uint256"},
+				"0xdb006a75": {"uint256"},
+				"0x5c658165": {"address", "address"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewSolidityParserStr(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := p.GetFunctions()
+			for sign, want := range tt.want {
+				if !reflect.DeepEqual(got[sign].Arguments, want) {
+					t.Errorf("GetFunctions() %s arguments got = %v, want %v", sign, got[sign].Arguments, want)
+				}
+			}
+		})
+	}
+}
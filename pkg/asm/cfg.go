@@ -0,0 +1,240 @@
+package asm
+
+import (
+	"github.com/ethereum/go-ethereum/core/vm"
+	"sort"
+)
+
+type EdgeKind int
+
+const (
+	// FallThrough is the edge to the next block when execution continues without a jump
+	FallThrough EdgeKind = iota
+	// Jump is the edge to the destination of a JUMP or a taken JUMPI
+	Jump
+)
+
+func (k EdgeKind) String() string {
+	if k == Jump {
+		return "jump"
+	}
+	return "fallthrough"
+}
+
+type Edge struct {
+	From *BasicBlock
+	To   *BasicBlock
+	Kind EdgeKind
+}
+
+// BasicBlock is a maximal sequence of instructions with a single entry at the first and a single exit at the last
+type BasicBlock struct {
+	// Start and End are the PCs of the first and the last instruction of the block
+	Start        uint64
+	End          uint64
+	Instructions []Instruction
+	Successors   []Edge
+	Predecessors []Edge
+	// UnresolvedJump is set when the block ends in a JUMP or JUMPI whose destination is not known statically
+	UnresolvedJump bool
+}
+
+func (b *BasicBlock) Last() Instruction {
+	return b.Instructions[len(b.Instructions)-1]
+}
+
+// CFG is the control flow graph of the disassembled bytecode
+type CFG struct {
+	Disassembler
+	// Blocks map of the start PC to the basic block
+	Blocks map[uint64]*BasicBlock
+	Edges  []Edge
+
+	sorted []*BasicBlock
+}
+
+// NewCFG splits the instructions into basic blocks and connects them. Jump destinations are resolved from the
+// PUSH immediately preceding the JUMP or JUMPI, every other jump is marked as unresolved
+func NewCFG(d Disassembler) CFG {
+	c := CFG{
+		Disassembler: d,
+		Blocks:       make(map[uint64]*BasicBlock),
+		Edges:        make([]Edge, 0),
+	}
+	if len(d.Instructions) == 0 {
+		return c
+	}
+
+	var current *BasicBlock
+	for i, inst := range d.Instructions {
+		if current == nil || inst.Op == vm.JUMPDEST {
+			current = &BasicBlock{Start: inst.PC}
+			c.Blocks[inst.PC] = current
+			c.sorted = append(c.sorted, current)
+		}
+		current.Instructions = append(current.Instructions, inst)
+		current.End = inst.PC
+		if endsBlock(inst.Op) || i == len(d.Instructions)-1 {
+			current = nil
+		}
+	}
+
+	for _, block := range c.SortedBlocks() {
+		last := block.Last()
+		if last.Op == vm.JUMP || last.Op == vm.JUMPI {
+			if dest, ok := block.staticJumpDestination(); ok {
+				if to, ok := c.jumpDestinationBlock(dest); ok {
+					c.addEdge(block, to, Jump)
+				}
+			} else {
+				block.UnresolvedJump = true
+			}
+		}
+		if !haltsFlow(last.Op) && last.Op != vm.JUMP {
+			if next, ok := c.nextBlock(block); ok {
+				c.addEdge(block, next, FallThrough)
+			}
+		}
+	}
+	return c
+}
+
+// ResolveDynamicJumps uses the Emulator to find the destinations of jumps which could not be resolved statically,
+// like the returns from Solidity internal functions. Jumps without any destination found remain unresolved
+func (c *CFG) ResolveDynamicJumps(e Emulator) {
+	c.ResolveDynamicJumpsFrom(e, 0)
+}
+
+// ResolveDynamicJumpsFrom resolves dynamic jumps only along the paths starting at pc, for e.g. a function entry
+func (c *CFG) ResolveDynamicJumpsFrom(e Emulator, pc uint64) {
+	resolved := make(map[uint64]map[uint64]bool)
+	e.RunFrom(pc, nil, func(inst Instruction, state *ExecutionState) bool {
+		if inst.Op != vm.JUMP && inst.Op != vm.JUMPI {
+			return true
+		}
+		dest := state.Peek(0)
+		if dest.IsKnown() && dest.Value.IsUint64() {
+			if resolved[inst.PC] == nil {
+				resolved[inst.PC] = make(map[uint64]bool)
+			}
+			resolved[inst.PC][dest.Value.Uint64()] = true
+		}
+		return true
+	})
+	for _, block := range c.SortedBlocks() {
+		if !block.UnresolvedJump {
+			continue
+		}
+		dests := make([]uint64, 0, len(resolved[block.End]))
+		for dest := range resolved[block.End] {
+			dests = append(dests, dest)
+		}
+		sort.Slice(dests, func(i, j int) bool { return dests[i] < dests[j] })
+		for _, dest := range dests {
+			if to, ok := c.jumpDestinationBlock(dest); ok {
+				c.addEdge(block, to, Jump)
+				block.UnresolvedJump = false
+			}
+		}
+	}
+}
+
+// BlockAt returns the block containing the instruction at pc
+func (c CFG) BlockAt(pc uint64) (*BasicBlock, bool) {
+	blocks := c.SortedBlocks()
+	i := sort.Search(len(blocks), func(i int) bool {
+		return blocks[i].End >= pc
+	})
+	if i < len(blocks) && blocks[i].Start <= pc {
+		return blocks[i], true
+	}
+	return nil, false
+}
+
+// SortedBlocks returns the blocks ordered by their start PC
+func (c CFG) SortedBlocks() []*BasicBlock {
+	return c.sorted
+}
+
+// Reachable returns the blocks reachable from the block starting at pc, ordered by their start PC
+func (c CFG) Reachable(pc uint64) []*BasicBlock {
+	res := make([]*BasicBlock, 0)
+	start, ok := c.Blocks[pc]
+	if !ok {
+		return res
+	}
+	seen := map[uint64]bool{start.Start: true}
+	pending := []*BasicBlock{start}
+	for len(pending) > 0 {
+		block := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		res = append(res, block)
+		for _, edge := range block.Successors {
+			if !seen[edge.To.Start] {
+				seen[edge.To.Start] = true
+				pending = append(pending, edge.To)
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Start < res[j].Start })
+	return res
+}
+
+func (c *CFG) addEdge(from *BasicBlock, to *BasicBlock, kind EdgeKind) {
+	for _, edge := range from.Successors {
+		if edge.To == to && edge.Kind == kind {
+			return
+		}
+	}
+	edge := Edge{From: from, To: to, Kind: kind}
+	from.Successors = append(from.Successors, edge)
+	to.Predecessors = append(to.Predecessors, edge)
+	c.Edges = append(c.Edges, edge)
+}
+
+func (c CFG) jumpDestinationBlock(dest uint64) (*BasicBlock, bool) {
+	if _, ok := c.JumpDestinations[dest]; !ok {
+		return nil, false
+	}
+	block, ok := c.Blocks[dest]
+	return block, ok
+}
+
+func (c CFG) nextBlock(block *BasicBlock) (*BasicBlock, bool) {
+	i, ok := c.InstructionIndex(block.End)
+	if !ok || i+1 >= len(c.Instructions) {
+		return nil, false
+	}
+	next, ok := c.Blocks[c.Instructions[i+1].PC]
+	return next, ok
+}
+
+// staticJumpDestination returns the destination pushed right before the terminating jump of the block
+func (b *BasicBlock) staticJumpDestination() (uint64, bool) {
+	if len(b.Instructions) < 2 {
+		return 0, false
+	}
+	prev := b.Instructions[len(b.Instructions)-2]
+	if !prev.Op.IsPush() || len(prev.Arg) > 8 {
+		return 0, false
+	}
+	var dest uint64
+	for _, by := range prev.Arg {
+		dest = dest<<8 | uint64(by)
+	}
+	return dest, true
+}
+
+func endsBlock(op vm.OpCode) bool {
+	return op == vm.JUMP || op == vm.JUMPI || haltsFlow(op)
+}
+
+// haltsFlow reports whether execution never continues to the next instruction
+func haltsFlow(op vm.OpCode) bool {
+	switch op {
+	case vm.STOP, vm.RETURN, vm.REVERT, vm.INVALID, vm.SELFDESTRUCT:
+		return true
+	}
+	_, _, ok := stackEffect(op)
+	return !ok && !op.IsPush() && op != vm.PUSH0 && !(op >= vm.DUP1 && op <= vm.SWAP16)
+}
@@ -0,0 +1,96 @@
+package asm
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	fallThroughColour = "#7f7f7f"
+	jumpColour        = "#1f77b4"
+)
+
+func edgeColour(kind EdgeKind) string {
+	if kind == Jump {
+		return jumpColour
+	}
+	return fallThroughColour
+}
+
+// DOT renders the given blocks and the edges between them as a Graphviz digraph
+func (c CFG) DOT(blocks []*BasicBlock) string {
+	included := blockSet(blocks)
+	var sb strings.Builder
+	sb.WriteString("digraph cfg {\n")
+	sb.WriteString("\tnode [shape=box fontname=\"monospace\"];\n")
+	for _, block := range blocks {
+		lines := make([]string, 0, len(block.Instructions))
+		for _, inst := range block.Instructions {
+			lines = append(lines, dotEscape(inst.String()))
+		}
+		style := ""
+		if block.UnresolvedJump {
+			style = " style=dashed"
+		}
+		sb.WriteString(fmt.Sprintf("\t%s [label=\"%s\\l\"%s];\n", blockId(block), strings.Join(lines, "\\l"), style))
+	}
+	for _, block := range blocks {
+		for _, edge := range block.Successors {
+			if !included[edge.To.Start] {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\t%s -> %s [color=\"%s\" label=\"%s\"];\n",
+				blockId(edge.From), blockId(edge.To), edgeColour(edge.Kind), edge.Kind))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid renders the given blocks and the edges between them as a Mermaid flowchart
+func (c CFG) Mermaid(blocks []*BasicBlock) string {
+	included := blockSet(blocks)
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	for _, block := range blocks {
+		lines := make([]string, 0, len(block.Instructions))
+		for _, inst := range block.Instructions {
+			lines = append(lines, mermaidEscape(inst.String()))
+		}
+		sb.WriteString(fmt.Sprintf("\t%s[\"%s\"]\n", blockId(block), strings.Join(lines, "<br/>")))
+	}
+	linkStyles := make([]string, 0)
+	for _, block := range blocks {
+		for _, edge := range block.Successors {
+			if !included[edge.To.Start] {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\t%s -->|%s| %s\n", blockId(edge.From), edge.Kind, blockId(edge.To)))
+			linkStyles = append(linkStyles, fmt.Sprintf("\tlinkStyle %d stroke:%s\n", len(linkStyles), edgeColour(edge.Kind)))
+		}
+	}
+	for _, style := range linkStyles {
+		sb.WriteString(style)
+	}
+	return sb.String()
+}
+
+func blockSet(blocks []*BasicBlock) map[uint64]bool {
+	res := make(map[uint64]bool, len(blocks))
+	for _, block := range blocks {
+		res[block.Start] = true
+	}
+	return res
+}
+
+func blockId(block *BasicBlock) string {
+	return fmt.Sprintf("b%05x", block.Start)
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,157 @@
+package asm
+
+import (
+	"testing"
+)
+
+func TestNewCFG(t *testing.T) {
+	type wantEdge struct {
+		from uint64
+		to   uint64
+		kind EdgeKind
+	}
+	tests := []struct {
+		name           string
+		code           string
+		resolveDynamic bool
+		wantBlocks     []uint64
+		wantEdges      []wantEdge
+		wantUnresolved []uint64
+	}{
+		{
+			name: "Static JUMPI with fall through and dynamic JUMP",
+			// PUSH1 0 CALLDATALOAD PUSH1 0x0a JUMPI PUSH1 0 DUP1 REVERT JUMPDEST CALLER JUMP
+			code:           "600035600a57600080fd5b3356",
+			wantBlocks:     []uint64{0x00, 0x06, 0x0a},
+			wantEdges:      []wantEdge{{0x00, 0x0a, Jump}, {0x00, 0x06, FallThrough}},
+			wantUnresolved: []uint64{0x0a},
+		},
+		{
+			name: "Join block with a jump and a fall through predecessor",
+			// PUSH1 0 CALLDATALOAD PUSH1 8 JUMPI PUSH1 1 JUMPDEST STOP
+			code:       "60003560085760015b00",
+			wantBlocks: []uint64{0x00, 0x06, 0x08},
+			wantEdges:  []wantEdge{{0x00, 0x08, Jump}, {0x00, 0x06, FallThrough}, {0x06, 0x08, FallThrough}},
+		},
+		{
+			name: "Internal function return resolved by emulation",
+			// PUSH1 7 PUSH1 0x0a JUMP INVALID INVALID JUMPDEST STOP INVALID JUMPDEST JUMP
+			code:           "6007600a56fefe5b00fe5b56",
+			resolveDynamic: true,
+			wantBlocks:     []uint64{0x00, 0x05, 0x06, 0x07, 0x09, 0x0a},
+			wantEdges:      []wantEdge{{0x00, 0x0a, Jump}, {0x0a, 0x07, Jump}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewTestDisassembler(tt.code)
+			c := NewCFG(d)
+			if tt.resolveDynamic {
+				c.ResolveDynamicJumps(NewEmulator(d))
+			}
+			if len(c.Blocks) != len(tt.wantBlocks) {
+				t.Errorf("NewCFG() blocks = %v, want %v", len(c.Blocks), len(tt.wantBlocks))
+			}
+			for _, pc := range tt.wantBlocks {
+				if _, ok := c.Blocks[pc]; !ok {
+					t.Errorf("NewCFG() block at %#x not found", pc)
+				}
+			}
+			if len(c.Edges) != len(tt.wantEdges) {
+				t.Errorf("NewCFG() edges = %v, want %v", len(c.Edges), len(tt.wantEdges))
+			}
+			for _, want := range tt.wantEdges {
+				found := false
+				for _, edge := range c.Blocks[want.from].Successors {
+					found = found || (edge.To.Start == want.to && edge.Kind == want.kind)
+				}
+				if !found {
+					t.Errorf("NewCFG() %v edge %#x -> %#x not found in the successors", want.kind, want.from, want.to)
+				}
+				found = false
+				for _, edge := range c.Blocks[want.to].Predecessors {
+					found = found || (edge.From.Start == want.from && edge.Kind == want.kind)
+				}
+				if !found {
+					t.Errorf("NewCFG() %v edge %#x -> %#x not found in the predecessors", want.kind, want.from, want.to)
+				}
+			}
+			for _, block := range c.SortedBlocks() {
+				wantUnresolved := false
+				for _, pc := range tt.wantUnresolved {
+					wantUnresolved = wantUnresolved || pc == block.Start
+				}
+				if block.UnresolvedJump != wantUnresolved {
+					t.Errorf("NewCFG() block %#x unresolved = %v, want %v", block.Start, block.UnresolvedJump, wantUnresolved)
+				}
+			}
+		})
+	}
+}
+
+func TestCFG_DispatcherTargets(t *testing.T) {
+	p := SolidityParser{Disassembler: NewTestDisassembler(usdTBytecode)}
+	c := NewCFG(p.Disassembler)
+	for _, inst := range p.Instructions {
+		if _, ok := c.BlockAt(inst.PC); !ok {
+			t.Fatalf("BlockAt() instruction at %#x not covered by any block", inst.PC)
+		}
+	}
+	if len(c.Reachable(0)) < len(p.GetFunctionSigns().Signatures) {
+		t.Errorf("Reachable() fewer blocks than function selectors")
+	}
+}
+
+func TestCFG_Export(t *testing.T) {
+	c := NewCFG(NewTestDisassembler("600035600a57600080fd5b3356"))
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "DOT",
+			got:  c.DOT(c.SortedBlocks()),
+			want: `digraph cfg {
+	node [shape=box fontname="monospace"];
+	b00000 [label="00000: PUSH1 0x00\l00002: CALLDATALOAD\l00003: PUSH1 0x0a\l00005: JUMPI\l"];
+	b00006 [label="00006: PUSH1 0x00\l00008: DUP1\l00009: REVERT\l"];
+	b0000a [label="0000a: JUMPDEST\l0000b: CALLER\l0000c: JUMP\l" style=dashed];
+	b00000 -> b0000a [color="#1f77b4" label="jump"];
+	b00000 -> b00006 [color="#7f7f7f" label="fallthrough"];
+}
+`,
+		},
+		{
+			name: "Mermaid",
+			got:  c.Mermaid(c.SortedBlocks()),
+			want: `flowchart TD
+	b00000["00000: PUSH1 0x00<br/>00002: CALLDATALOAD<br/>00003: PUSH1 0x0a<br/>00005: JUMPI"]
+	b00006["00006: PUSH1 0x00<br/>00008: DUP1<br/>00009: REVERT"]
+	b0000a["0000a: JUMPDEST<br/>0000b: CALLER<br/>0000c: JUMP"]
+	b00000 -->|jump| b0000a
+	b00000 -->|fallthrough| b00006
+	linkStyle 0 stroke:#1f77b4
+	linkStyle 1 stroke:#7f7f7f
+`,
+		},
+		{
+			name: "Edges to excluded blocks are dropped",
+			got:  c.DOT(c.SortedBlocks()[:2]),
+			want: `digraph cfg {
+	node [shape=box fontname="monospace"];
+	b00000 [label="00000: PUSH1 0x00\l00002: CALLDATALOAD\l00003: PUSH1 0x0a\l00005: JUMPI\l"];
+	b00006 [label="00006: PUSH1 0x00\l00008: DUP1\l00009: REVERT\l"];
+	b00000 -> b00006 [color="#7f7f7f" label="fallthrough"];
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("export got = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package asm
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// creationMaxSteps bounds the emulation of the constructor
+const creationMaxSteps = 200_000
+
+var ErrNotCreationCode = errors.New("no runtime code returned by the bytecode, not a creation code")
+
+// CreationCode is a creation bytecode split into its sections
+//
+//	<constructor code> <runtime code> <ABI encoded constructor arguments>
+type CreationCode struct {
+	Constructor []byte
+	Runtime     []byte
+	// RuntimeOffset is the offset of Runtime in the creation code
+	RuntimeOffset uint64
+	// ConstructorArgs are the bytes following the runtime code, empty when the constructor takes no arguments
+	ConstructorArgs []byte
+}
+
+type codeCopy struct {
+	offset uint64
+	size   uint64
+}
+
+// SplitCreationCode emulates the constructor to find the runtime code, which is copied into memory with CODECOPY
+// and returned with RETURN. Paths reading calldata are not followed since a constructor has none, this way the
+// RETURN of runtime code is not mistaken for a returned runtime
+func SplitCreationCode(code []byte) (CreationCode, error) {
+	d, err := NewDisassembler(code)
+	if err != nil && len(d.Instructions) == 0 {
+		return CreationCode{}, err
+	}
+	e := NewEmulator(d, WithMaxSteps(creationMaxSteps))
+	// copies maps memory destination to the last code region copied there
+	copies := make(map[uint64]codeCopy)
+	var runtime *codeCopy
+	e.Run(func(inst Instruction, state *ExecutionState) bool {
+		if runtime != nil {
+			return false
+		}
+		switch inst.Op {
+		case vm.CALLDATALOAD, vm.CALLDATASIZE, vm.CALLDATACOPY:
+			return false
+		case vm.CODECOPY:
+			dest, offset, size := state.Peek(0), state.Peek(1), state.Peek(2)
+			if dest.IsKnown() && offset.IsKnown() && size.IsKnown() && dest.Value.IsUint64() &&
+				offset.Value.IsUint64() && size.Value.IsUint64() {
+				copies[dest.Value.Uint64()] = codeCopy{offset: offset.Value.Uint64(), size: size.Value.Uint64()}
+			}
+		case vm.RETURN:
+			offset, size := state.Peek(0), state.Peek(1)
+			if !offset.IsKnown() || !size.IsKnown() || !offset.Value.IsUint64() || !size.Value.IsUint64() {
+				return true
+			}
+			// Immutables are written after the copied code, Vyper returns them along with the runtime
+			if copied, ok := copies[offset.Value.Uint64()]; ok && copied.size > 0 && copied.size <= size.Value.Uint64() &&
+				copied.offset > 0 && copied.offset+copied.size <= uint64(len(code)) {
+				runtime = &copied
+			}
+		}
+		return true
+	})
+	if runtime == nil {
+		return CreationCode{}, ErrNotCreationCode
+	}
+	end := runtime.offset + runtime.size
+	return CreationCode{
+		Constructor:     code[:runtime.offset],
+		Runtime:         code[runtime.offset:end],
+		RuntimeOffset:   runtime.offset,
+		ConstructorArgs: code[end:],
+	}, nil
+}
+
+// RuntimeCode returns the runtime section when code is a creation code, otherwise code itself
+func RuntimeCode(code []byte) []byte {
+	if creation, err := SplitCreationCode(code); err == nil {
+		return creation.Runtime
+	}
+	return code
+}
@@ -0,0 +1,114 @@
+package asm
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// vyperERC20CreationBytecode is hand assembled after the creation code of the Vyper ERC20 token with a single
+// __init__(_supply: uint256), which reads its argument from the end of the code, deploying vyperERC20LinearBytecode
+// with 1000 as the supply
+const vyperERC20CreationBytecode = "346100b657602061058860403960056000557f546f6b656e00000000000000000000000000000000000000000000000000000060015560" +
+	"036002557f544b4e0000000000000000000000000000000000000000000000000000000000600355601260045560405160056000523360205260" +
+	"4060002055604051600755336008553360007fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206040a36104" +
+	"cd806100bb6000396000f35b600080fd" + vyperERC20LinearBytecode +
+	"00000000000000000000000000000000000000000000000000000000000003e8"
+
+func TestSplitCreationCode(t *testing.T) {
+	tests := []struct {
+		name              string
+		code              string
+		wantRuntimeOffset uint64
+		wantRuntimeSize   int
+		wantArgs          *big.Int
+		wantErr           error
+	}{
+		{
+			name:              "Creation code with constructor arguments - Simple Token",
+			code:              simpleTokenBytecode,
+			wantRuntimeOffset: 0x8f,
+			wantRuntimeSize:   0xc3f,
+			wantArgs:          big.NewInt(10000),
+		},
+		{
+			name:              "Creation code with constructor arguments - Vyper ERC20",
+			code:              vyperERC20CreationBytecode,
+			wantRuntimeOffset: 0xbb,
+			wantRuntimeSize:   0x4cd,
+			wantArgs:          big.NewInt(1000),
+		},
+		{
+			name:    "Runtime code - Simple Token 2",
+			code:    simpleTokenBytecode2,
+			wantErr: ErrNotCreationCode,
+		},
+		{
+			name:    "Runtime code - USDT",
+			code:    usdTBytecode,
+			wantErr: ErrNotCreationCode,
+		},
+		{
+			name:    "Runtime code - Vyper",
+			code:    vyperDenseBytecode,
+			wantErr: ErrNotCreationCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := hex.DecodeString(tt.code)
+			got, err := SplitCreationCode(code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SplitCreationCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.RuntimeOffset != tt.wantRuntimeOffset || len(got.Runtime) != tt.wantRuntimeSize ||
+				len(got.Constructor) != int(tt.wantRuntimeOffset) {
+				t.Errorf("SplitCreationCode() runtime at %#x of size %#x, want %#x of size %#x", got.RuntimeOffset,
+					len(got.Runtime), tt.wantRuntimeOffset, tt.wantRuntimeSize)
+			}
+			if new(big.Int).SetBytes(got.ConstructorArgs).Cmp(tt.wantArgs) != 0 || len(got.ConstructorArgs) != 32 {
+				t.Errorf("SplitCreationCode() constructor args = %x, want %v", got.ConstructorArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestNewSolidityParser_CreationCode(t *testing.T) {
+	p, err := NewSolidityParserStr(simpleTokenBytecode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFunctionSigns := []string{"0x06fdde03", "0x095ea7b3", "0x18160ddd", "0x23b872dd", "0x313ce567", "0x70a08231",
+		"0x95d89b41", "0xa9059cbb", "0xdd62ed3e"}
+	got := p.GetFunctionSigns()
+	if len(got.Signatures) != len(wantFunctionSigns) {
+		t.Errorf("GetFunctionSigns() length does not match wanted = %v, got = %v", len(wantFunctionSigns), len(got.Signatures))
+	}
+	for _, sign := range wantFunctionSigns {
+		if found := got.Signatures[sign]; !found {
+			t.Errorf("GetFunctionSigns() wanted = %v but not found", sign)
+		}
+	}
+}
+
+func TestNewVyperParser_CreationCode(t *testing.T) {
+	p, err := NewVyperParserStr(vyperERC20CreationBytecode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFunctionSigns := []string{"0x06fdde03", "0x095ea7b3", "0x18160ddd", "0x23b872dd", "0x313ce567", "0x40c10f19",
+		"0x42966c68", "0x70a08231", "0x79cc6790", "0x95d89b41", "0xa9059cbb", "0xdd62ed3e"}
+	got := p.GetFunctionSigns()
+	if len(got.Signatures) != len(wantFunctionSigns) {
+		t.Errorf("GetFunctionSigns() length does not match wanted = %v, got = %v", len(wantFunctionSigns), len(got.Signatures))
+	}
+	for _, sign := range wantFunctionSigns {
+		if found := got.Signatures[sign]; !found {
+			t.Errorf("GetFunctionSigns() wanted = %v but not found", sign)
+		}
+	}
+}
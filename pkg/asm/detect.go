@@ -0,0 +1,172 @@
+package asm
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"strings"
+)
+
+type Compiler string
+
+const (
+	Solidity Compiler = "solidity"
+	Vyper    Compiler = "vyper"
+	// AutoDetect selects the compiler from the bytecode, see DetectCompiler
+	AutoDetect Compiler = "auto"
+)
+
+// ParseCompiler parses the compiler name as accepted on the CLI
+func ParseCompiler(name string) (Compiler, error) {
+	switch c := Compiler(strings.ToLower(name)); c {
+	case Solidity, Vyper, AutoDetect:
+		return c, nil
+	}
+	return "", fmt.Errorf("unsupported compiler %s, expected one of %s, %s, %s", name, AutoDetect, Solidity, Vyper)
+}
+
+const (
+	// metadataConfidence is used when the compiler is named in the metadata trailer
+	metadataConfidence = 0.95
+	// maxHeuristicConfidence caps the confidence from prologue and dispatcher shape
+	maxHeuristicConfidence = 0.9
+	prologueWeight         = 0.5
+	dispatcherWeight       = 0.3
+	// selectorTableWindow is the number of leading instructions searched for the selector table lookup
+	selectorTableWindow = 64
+)
+
+var (
+	// solidityPrologues are the free memory pointer initialisations emitted by solc, MSTORE(0x40, 0x80/0x60)
+	solidityPrologues = [][]byte{{0x60, 0x80, 0x60, 0x40, 0x52}, {0x60, 0x60, 0x60, 0x40, 0x52}}
+	// vyperPrologue is MSTORE(0x1c, CALLDATALOAD(0)) of Vyper < 0.3
+	vyperPrologue = []byte{0x60, 0x00, 0x35, 0x60, 0x1c, 0x52}
+	// vyperCalldataSizeCheck precedes vyperPrologue in Vyper 0.2, shorter calldata goes to the fallback
+	//
+	//	PUSH1 4 CALLDATASIZE LT ISZERO PUSH2 0x000d JUMPI PUSH2 <fallback> JUMP JUMPDEST
+	vyperCalldataSizeCheck = []byte{0x60, 0x04, 0x36, 0x10, 0x15, 0x61, 0x00, 0x0d, 0x57, 0x61}
+)
+
+// Detection is the compiler which most likely produced the bytecode
+type Detection struct {
+	Compiler Compiler
+	// Confidence is between 0 and 1, 0 when nothing hinted at a compiler and Solidity was assumed
+	Confidence float64
+	// Evidence describes the hints the detection is based on
+	Evidence []string
+}
+
+// DetectCompiler inspects the bytecode for the metadata trailer, the known prologues and the shape of the
+// selector dispatcher. Solidity is assumed when there is no hint
+func DetectCompiler(d Disassembler) Detection {
+	if metadata, err := ParseMetadata(d.OriginalByteCode); err == nil {
+		evidence := fmt.Sprintf("metadata trailer of %s", metadata.Compiler)
+		if metadata.Version != "" {
+			evidence += " " + metadata.Version
+		}
+		return Detection{Compiler: metadata.Compiler, Confidence: metadataConfidence, Evidence: []string{evidence}}
+	}
+
+	scores := map[Compiler]float64{}
+	evidence := make([]string, 0)
+	for _, prologue := range solidityPrologues {
+		if bytes.HasPrefix(d.OriginalByteCode, prologue) {
+			scores[Solidity] += prologueWeight
+			evidence = append(evidence, fmt.Sprintf("solidity prologue %x", prologue))
+		}
+	}
+	if hasVyperPrologue(d.OriginalByteCode) {
+		scores[Vyper] += prologueWeight
+		evidence = append(evidence, fmt.Sprintf("vyper prologue %x", vyperPrologue))
+	}
+
+	eqChecks, xorChecks := countSelectorComparisons(d)
+	if eqChecks > xorChecks {
+		scores[Solidity] += dispatcherWeight
+		evidence = append(evidence, fmt.Sprintf("%d EQ selector comparisons", eqChecks))
+	} else if xorChecks > eqChecks {
+		scores[Vyper] += dispatcherWeight
+		evidence = append(evidence, fmt.Sprintf("%d XOR selector comparisons", xorChecks))
+	}
+	if scores[Solidity] == scores[Vyper] && hasSelectorTableLookup(d) {
+		// Only the selector tables of Vyper are left, they can only be found by emulation
+		vyperEntries := len(VyperParser{Disassembler: d}.GetFunctionEntries())
+		if vyperEntries > len(FindDispatchEntries(d)) {
+			scores[Vyper] += dispatcherWeight
+			evidence = append(evidence, fmt.Sprintf("%d functions in vyper selector tables", vyperEntries))
+		}
+	}
+
+	res := Detection{Compiler: Solidity, Evidence: evidence}
+	if scores[Vyper] > scores[Solidity] {
+		res.Compiler = Vyper
+	}
+	res.Confidence = scores[res.Compiler] - scores[otherCompiler(res.Compiler)]
+	if res.Confidence > maxHeuristicConfidence {
+		res.Confidence = maxHeuristicConfidence
+	}
+	return res
+}
+
+// NewBytecodeParser returns the parser for the compiler, AutoDetect uses the result of DetectCompiler
+func NewBytecodeParser(d Disassembler, compiler Compiler) (BytecodeParser, Detection) {
+	detection := Detection{Compiler: compiler, Confidence: 1, Evidence: []string{"compiler set explicitly"}}
+	if compiler == AutoDetect {
+		detection = DetectCompiler(d)
+	}
+	if detection.Compiler == Vyper {
+		return VyperParser{Disassembler: d}, detection
+	}
+	return SolidityParser{Disassembler: d}, detection
+}
+
+// hasVyperPrologue matches vyperPrologue at the start of the code or after vyperCalldataSizeCheck
+func hasVyperPrologue(code []byte) bool {
+	if bytes.HasPrefix(code, vyperPrologue) {
+		return true
+	}
+	// the fallback label and JUMP JUMPDEST follow the size check
+	start := len(vyperCalldataSizeCheck) + 4
+	return bytes.HasPrefix(code, vyperCalldataSizeCheck) && len(code) > start &&
+		vm.OpCode(code[start-2]) == vm.JUMP && vm.OpCode(code[start-1]) == vm.JUMPDEST &&
+		bytes.HasPrefix(code[start:], vyperPrologue)
+}
+
+// countSelectorComparisons counts the selector comparisons of solc (DUP1 PUSH4 EQ, PUSH4 DUP2 EQ) and of Vyper
+// (PUSH4 DUP2 XOR)
+func countSelectorComparisons(d Disassembler) (eqChecks int, xorChecks int) {
+	for i := 0; i+2 < len(d.Instructions); i++ {
+		a, b, c := d.Instructions[i], d.Instructions[i+1], d.Instructions[i+2]
+		switch {
+		case a.Op == vm.DUP1 && b.Op == vm.PUSH4 && c.Op == vm.EQ, a.Op == vm.PUSH4 && b.Op == vm.DUP2 && c.Op == vm.EQ:
+			eqChecks++
+		case a.Op == vm.PUSH4 && b.Op == vm.DUP2 && c.Op == vm.XOR:
+			xorChecks++
+		}
+	}
+	return eqChecks, xorChecks
+}
+
+// hasSelectorTableLookup matches the start of the Vyper >= 0.3.10 dispatcher, the bucket of the selector is read from
+// the table in the code with MOD followed by CODECOPY. It gates the emulation of the dispatcher in DetectCompiler
+func hasSelectorTableLookup(d Disassembler) bool {
+	mod := false
+	for i := 0; i < len(d.Instructions) && i < selectorTableWindow; i++ {
+		switch d.Instructions[i].Op {
+		case vm.MOD:
+			mod = true
+		case vm.CODECOPY:
+			if mod {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func otherCompiler(c Compiler) Compiler {
+	if c == Vyper {
+		return Solidity
+	}
+	return Vyper
+}
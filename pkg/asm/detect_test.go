@@ -0,0 +1,122 @@
+package asm
+
+import (
+	"testing"
+)
+
+func TestDetectCompiler(t *testing.T) {
+	tests := []struct {
+		name           string
+		code           string
+		wantCompiler   Compiler
+		wantConfidence float64
+	}{
+		{
+			name:           "Solidity metadata - Simple Token 2",
+			code:           simpleTokenBytecode2,
+			wantCompiler:   Solidity,
+			wantConfidence: metadataConfidence,
+		},
+		{
+			name:           "Solidity bzzr0 metadata - USDT",
+			code:           usdTBytecode,
+			wantCompiler:   Solidity,
+			wantConfidence: metadataConfidence,
+		},
+		{
+			name:           "Vyper metadata",
+			code:           vyperLinearBytecode + "a165767970657283000307000b",
+			wantCompiler:   Vyper,
+			wantConfidence: metadataConfidence,
+		},
+		{
+			name:           "Solidity prologue and dispatcher",
+			code:           splitDispatcherBytecode,
+			wantCompiler:   Solidity,
+			wantConfidence: prologueWeight + dispatcherWeight,
+		},
+		{
+			name:           "Vyper prologue",
+			code:           vyperMloadBytecode,
+			wantCompiler:   Vyper,
+			wantConfidence: prologueWeight,
+		},
+		{
+			name:           "Vyper 0.2 prologue after the calldata size check",
+			code:           vyperERC20MloadBytecode,
+			wantCompiler:   Vyper,
+			wantConfidence: prologueWeight,
+		},
+		{
+			name:           "Vyper XOR dispatcher",
+			code:           vyperLinearBytecode,
+			wantCompiler:   Vyper,
+			wantConfidence: dispatcherWeight,
+		},
+		{
+			name:           "Vyper selector table",
+			code:           vyperDenseBytecode,
+			wantCompiler:   Vyper,
+			wantConfidence: dispatcherWeight,
+		},
+		{
+			name:           "No hint",
+			code:           "600035600a57600080fd5b3356",
+			wantCompiler:   Solidity,
+			wantConfidence: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectCompiler(NewTestDisassembler(tt.code))
+			if got.Compiler != tt.wantCompiler || got.Confidence != tt.wantConfidence {
+				t.Errorf("DetectCompiler() = %v (%v), want %v (%v), evidence = %v", got.Compiler, got.Confidence,
+					tt.wantCompiler, tt.wantConfidence, got.Evidence)
+			}
+		})
+	}
+}
+
+func TestNewBytecodeParser(t *testing.T) {
+	d := NewTestDisassembler(vyperLinearBytecode)
+	if p, detection := NewBytecodeParser(d, Solidity); detection.Compiler != Solidity || detection.Confidence != 1 {
+		t.Errorf("NewBytecodeParser() override = %T %+v", p, detection)
+	}
+	if p, _ := NewBytecodeParser(d, AutoDetect); !isVyperParser(p) {
+		t.Errorf("NewBytecodeParser() auto detect = %T", p)
+	}
+	if _, err := ParseCompiler("Vyper"); err != nil {
+		t.Errorf("ParseCompiler() error = %v", err)
+	}
+	if _, err := ParseCompiler("fe"); err == nil {
+		t.Errorf("ParseCompiler() expected error for fe")
+	}
+}
+
+func isVyperParser(p BytecodeParser) bool {
+	_, ok := p.(VyperParser)
+	return ok
+}
+
+func TestHasSelectorTableLookup(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{name: "Sparse selector table", code: vyperSparseBytecode, want: true},
+		{name: "Dense selector table", code: vyperDenseBytecode, want: true},
+		{name: "Sparse selector table - ERC20", code: vyperERC20SparseBytecode, want: true},
+		{name: "Dense selector table - ERC20", code: vyperERC20DenseBytecode, want: true},
+		{name: "Vyper XOR dispatcher", code: vyperLinearBytecode, want: false},
+		{name: "Solidity split dispatcher", code: splitDispatcherBytecode, want: false},
+		{name: "No dispatcher", code: "600035600a57600080fd5b3356", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasSelectorTableLookup(NewTestDisassembler(tt.code)); got != tt.want {
+				t.Errorf("hasSelectorTableLookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
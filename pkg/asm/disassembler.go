@@ -5,6 +5,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/asm"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"math/big"
+	"sort"
 )
 
 // Instruction is to encapsulate an EVM instruction
@@ -14,21 +15,36 @@ type Instruction struct {
 	Arg []byte
 }
 
+// String formats the instruction as <pc>: <opcode> <arg>
+func (i Instruction) String() string {
+	if i.Arg != nil && 0 < len(i.Arg) {
+		return fmt.Sprintf("%05x: %v %#x", i.PC, i.Op, i.Arg)
+	}
+	return fmt.Sprintf("%05x: %v", i.PC, i.Op)
+}
+
 type Disassembler struct {
 	OriginalByteCode []byte
 	Instructions     []Instruction
 	// JumpDestinations map of Program Counter to Instruction id in Instructions
 	JumpDestinations map[uint64]int
+	// Metadata is the trailer appended by the compiler, nil when the bytecode has none
+	Metadata *Metadata
 }
 
-// NewDisassembler accepts the EVM bytecode as a bytea
+// NewDisassembler accepts the EVM bytecode as a bytea. The metadata trailer is not disassembled
 func NewDisassembler(evmCode []byte) (Disassembler, error) {
 	d := Disassembler{
 		OriginalByteCode: evmCode,
 		Instructions:     make([]Instruction, 0),
 		JumpDestinations: make(map[uint64]int, 0),
 	}
-	it := asm.NewInstructionIterator(evmCode)
+	code := evmCode
+	if metadata, err := ParseMetadata(evmCode); err == nil {
+		d.Metadata = &metadata
+		code = evmCode[:len(evmCode)-metadata.Length]
+	}
+	it := asm.NewInstructionIterator(code)
 	for it.Next() {
 		d.Instructions = append(d.Instructions, Instruction{
 			PC:  it.PC(),
@@ -56,12 +72,19 @@ func (d Disassembler) GetOperationAtOffset(offset *big.Int) Instruction {
 	return d.Instructions[instNumber]
 }
 
+// InstructionIndex returns the position in Instructions of the instruction starting at pc
+func (d Disassembler) InstructionIndex(pc uint64) (int, bool) {
+	i := sort.Search(len(d.Instructions), func(i int) bool {
+		return d.Instructions[i].PC >= pc
+	})
+	if i < len(d.Instructions) && d.Instructions[i].PC == pc {
+		return i, true
+	}
+	return 0, false
+}
+
 func (d Disassembler) PrintDisassembled() {
 	for _, inst := range d.Instructions {
-		if inst.Arg != nil && 0 < len(inst.Arg) {
-			fmt.Printf("%05x: %v %#x\n", inst.PC, inst.Op, inst.Arg)
-		} else {
-			fmt.Printf("%05x: %v\n", inst.PC, inst.Op)
-		}
+		fmt.Println(inst)
 	}
 }
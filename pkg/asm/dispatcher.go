@@ -0,0 +1,133 @@
+package asm
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+)
+
+var (
+	selectorMask    = big.NewInt(0xffffffff)
+	selectorDivisor = new(big.Int).Lsh(big.NewInt(1), 224)
+)
+
+// selectorCheck is a JUMPI condition comparing the function selector against a constant
+type selectorCheck struct {
+	Sign     string
+	Constant StackValue
+	// Negated is set when the JUMPI is taken when the selector does not match
+	Negated bool
+}
+
+// IsInline reports whether the compared signature is pushed by the code rather than loaded from a data table
+func (c selectorCheck) IsInline() bool {
+	return c.Constant.Op == vm.PUSH4
+}
+
+// FindDispatchEntries walks the selector dispatch tree by emulating the bytecode from its first instruction and
+// returns the map of function signature to the PC where the function body starts.
+//
+// Every JUMPI whose condition compares the selector loaded from calldata against a constant is treated as a
+// dispatch branch, independent of the operand order (DUP1 PUSH4 EQ / PUSH4 DUP2 EQ) and of whether the compiler
+// splits the selectors with GT/LT pivots. Paths are not followed into the function bodies
+func FindDispatchEntries(d Disassembler) map[string]uint64 {
+	entries := make(map[string]uint64)
+	if len(d.Instructions) == 0 {
+		return entries
+	}
+	e := NewEmulator(d)
+	bodies := make(map[uint64]bool)
+	e.Run(func(inst Instruction, state *ExecutionState) bool {
+		if bodies[inst.PC] {
+			return false
+		}
+		if inst.Op == vm.JUMPI {
+			if check, ok := matchSelectorCheck(state.Peek(1), IsSelectorValue); ok {
+				e.recordInlineEntry(inst, state, check, entries, bodies)
+			}
+		}
+		return true
+	})
+	return entries
+}
+
+// recordInlineEntry records the function body guarded by the selector check at the JUMPI inst
+func (e Emulator) recordInlineEntry(inst Instruction, state *ExecutionState, check selectorCheck,
+	entries map[string]uint64, bodies map[uint64]bool) {
+	if check.Negated {
+		// Jumps away when the selector does not match, the body follows the JUMPI
+		if i, ok := e.InstructionIndex(inst.PC); ok && i+1 < len(e.Instructions) {
+			entries[check.Sign] = e.Instructions[i+1].PC
+			bodies[e.Instructions[i+1].PC] = true
+		}
+		return
+	}
+	dest := state.Peek(0)
+	if !dest.IsKnown() || !dest.Value.IsUint64() {
+		return
+	}
+	if _, ok := e.JumpDestinations[dest.Value.Uint64()]; ok {
+		entries[check.Sign] = dest.Value.Uint64()
+		bodies[dest.Value.Uint64()] = true
+	}
+}
+
+// matchSelectorCheck matches EQ(selector, <BYTE4>) in either operand order, XOR(selector, <BYTE4>) which is non
+// zero on mismatch and any ISZERO around them
+func matchSelectorCheck(cond StackValue, isSelector func(StackValue) bool) (selectorCheck, bool) {
+	if cond.IsKnown() || len(cond.Args) == 0 {
+		return selectorCheck{}, false
+	}
+	switch cond.Op {
+	case vm.ISZERO:
+		check, ok := matchSelectorCheck(cond.Args[0], isSelector)
+		check.Negated = !check.Negated
+		return check, ok
+	case vm.EQ, vm.XOR:
+		for i, arg := range cond.Args {
+			other := cond.Args[1-i]
+			if arg.IsKnown() && arg.Value.BitLen() <= 32 && isSelector(other) {
+				return selectorCheck{
+					Sign:     hexutil.Encode(selectorBytes(arg.Value)),
+					Constant: arg,
+					Negated:  cond.Op == vm.XOR,
+				}, true
+			}
+		}
+	}
+	return selectorCheck{}, false
+}
+
+// IsSelectorValue reports whether v is the function selector extracted from the first 4 bytes of calldata
+//
+//	SHR(0xe0, CALLDATALOAD(0))
+//	DIV(CALLDATALOAD(0), 2^224)
+//	AND(0xffffffff, <selector>)
+func IsSelectorValue(v StackValue) bool {
+	if v.IsKnown() || len(v.Args) != 2 {
+		return false
+	}
+	switch v.Op {
+	case vm.SHR:
+		return v.Args[0].IsConst(224) && isCalldataWord(v.Args[1], 0)
+	case vm.DIV:
+		return v.Args[1].IsKnown() && v.Args[1].Value.Cmp(selectorDivisor) == 0 && isCalldataWord(v.Args[0], 0)
+	case vm.AND:
+		for i, arg := range v.Args {
+			if arg.IsKnown() && arg.Value.Cmp(selectorMask) == 0 {
+				return IsSelectorValue(v.Args[1-i])
+			}
+		}
+	}
+	return false
+}
+
+// isCalldataWord reports whether v is CALLDATALOAD(offset)
+func isCalldataWord(v StackValue, offset uint64) bool {
+	return !v.IsKnown() && v.Op == vm.CALLDATALOAD && len(v.Args) == 1 && v.Args[0].IsConst(offset)
+}
+
+func selectorBytes(v *big.Int) []byte {
+	res := make([]byte, 4)
+	return v.FillBytes(res)
+}
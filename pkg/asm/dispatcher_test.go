@@ -0,0 +1,61 @@
+package asm
+
+import (
+	"testing"
+)
+
+const (
+	// splitDispatcherBytecode is a hand assembled dispatcher with a GT pivot, PUSH4 DUP2 EQ comparisons and an
+	// inverted EQ ISZERO branch whose body follows the JUMPI
+	splitDispatcherBytecode = "60806040526004361061005f5760003560e01c806370a0823111610038576306fdde0381146100645763095ea7b3811461006657" +
+		"61005f565b8063a9059cbb141561004f57600160005260206000f35b63dd62ed3e81146100685761005f565b600080fd5b005b005b00"
+)
+
+func TestFindDispatchEntries(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		wantEntries map[string]uint64
+	}{
+		{
+			name: "Split dispatcher with reordered operands",
+			code: splitDispatcherBytecode,
+			wantEntries: map[string]uint64{
+				"0x06fdde03": 0x64,
+				"0x095ea7b3": 0x66,
+				"0xa9059cbb": 0x45,
+				"0xdd62ed3e": 0x68,
+			},
+		},
+		{
+			name: "Binary search dispatcher - Simple Token 2",
+			code: simpleTokenBytecode2,
+			wantEntries: map[string]uint64{
+				"0x06fdde03": 0x147, "0x095ea7b3": 0x165, "0x18160ddd": 0x185, "0x23b872dd": 0x19a,
+				"0x30adf81f": 0x1ad, "0x313ce567": 0x1b5, "0x3644e515": 0x1ca, "0x39509351": 0x1d2,
+				"0x5aa6e675": 0x1e5, "0x70a08231": 0x1fa, "0x715018a6": 0x20d, "0x7ecebe00": 0x217,
+				"0x8da5cb5b": 0x22a, "0x95d89b41": 0x232, "0xa457c2d7": 0x23a, "0xa7229fd9": 0x24d,
+				"0xa9059cbb": 0x260, "0xab033ea9": 0x273, "0xd505accf": 0x286, "0xd669e1d4": 0x299,
+				"0xdd62ed3e": 0x2a1, "0xf2fde38b": 0x2b4,
+			},
+		},
+		{
+			name:        "No dispatcher",
+			code:        "600035600a57600080fd5b3356",
+			wantEntries: map[string]uint64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindDispatchEntries(NewTestDisassembler(tt.code))
+			if len(got) != len(tt.wantEntries) {
+				t.Errorf("FindDispatchEntries() length does not match wanted = %v, got = %v", len(tt.wantEntries), len(got))
+			}
+			for sign, pc := range tt.wantEntries {
+				if gotPC, found := got[sign]; !found || gotPC != pc {
+					t.Errorf("FindDispatchEntries() wanted = %v at %#x, got = %#x (found = %v)", sign, pc, gotPC, found)
+				}
+			}
+		})
+	}
+}
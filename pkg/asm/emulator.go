@@ -0,0 +1,468 @@
+package asm
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultMaxSteps  = 2_000_000
+	defaultMaxVisits = 512
+	maxStackDepth    = 1024
+)
+
+var (
+	big256  = big.NewInt(256)
+	tt256   = new(big.Int).Lsh(big.NewInt(1), 256)
+	tt256m1 = new(big.Int).Sub(tt256, big.NewInt(1))
+)
+
+// StackValue is an abstract value on the EVM stack
+type StackValue struct {
+	// Value is nil when it cannot be determined statically
+	Value *big.Int
+	// PC and Op of the instruction which produced the value
+	PC uint64
+	Op vm.OpCode
+	// Args are the operands of Op, only kept when Value is unknown so that its origin can be inspected
+	Args []StackValue
+	// FromCalldata is set when the value is derived from CALLDATALOAD
+	FromCalldata bool
+}
+
+func (v StackValue) IsKnown() bool {
+	return v.Value != nil
+}
+
+// IsConst reports whether the value is known and equal to c
+func (v StackValue) IsConst(c uint64) bool {
+	return v.IsKnown() && v.Value.IsUint64() && v.Value.Uint64() == c
+}
+
+// ExecutionState is the abstract machine state along one execution path
+type ExecutionState struct {
+	// Stack holds the stack values, the top of the stack is the last element
+	Stack []StackValue
+
+	index  int
+	memory *memory
+}
+
+// Peek returns the n-th value from the top of the stack, Peek(0) being the top. Values below the known
+// stack are returned as unknown
+func (s *ExecutionState) Peek(n int) StackValue {
+	if n < 0 || n >= len(s.Stack) {
+		return StackValue{}
+	}
+	return s.Stack[len(s.Stack)-1-n]
+}
+
+func (s *ExecutionState) push(v StackValue) {
+	s.Stack = append(s.Stack, v)
+}
+
+func (s *ExecutionState) pop() StackValue {
+	if len(s.Stack) == 0 {
+		return StackValue{}
+	}
+	v := s.Stack[len(s.Stack)-1]
+	s.Stack = s.Stack[:len(s.Stack)-1]
+	return v
+}
+
+func (s *ExecutionState) fork(index int) *ExecutionState {
+	stack := make([]StackValue, len(s.Stack))
+	copy(stack, s.Stack)
+	return &ExecutionState{Stack: stack, index: index, memory: s.memory.copy()}
+}
+
+// key identifies the state for deduplication, values of unknown provenance are treated as equal
+func (s *ExecutionState) key(pc uint64) string {
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatUint(pc, 16))
+	for _, v := range s.Stack {
+		sb.WriteByte('|')
+		if v.IsKnown() {
+			sb.WriteString(v.Value.Text(16))
+		} else {
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// InstructionHook is invoked with the state before the instruction is executed. Returning false stops the
+// exploration of the current path
+type InstructionHook func(inst Instruction, state *ExecutionState) bool
+
+// Emulator is an abstract interpreter over the disassembled bytecode. It follows every path whose jump targets
+// can be resolved from constants on the stack, tracking which stack values are known statically
+type Emulator struct {
+	Disassembler
+
+	maxSteps    int
+	maxVisits   int
+	trackMemory bool
+	calldata    []byte
+}
+
+type EmulatorOpt func(emulator *Emulator)
+
+// WithMaxSteps limits the total number of instructions executed across all paths
+func WithMaxSteps(steps int) EmulatorOpt {
+	return func(emulator *Emulator) {
+		emulator.maxSteps = steps
+	}
+}
+
+// WithMaxVisits limits the number of distinct states in which a single block is entered
+func WithMaxVisits(visits int) EmulatorOpt {
+	return func(emulator *Emulator) {
+		emulator.maxVisits = visits
+	}
+}
+
+// WithMemory tracks the memory bytes written from known values and code, so that MLOAD of them is known
+func WithMemory() EmulatorOpt {
+	return func(emulator *Emulator) {
+		emulator.trackMemory = true
+	}
+}
+
+// WithCalldata makes CALLDATALOAD and CALLDATACOPY read from the given calldata instead of being unknown
+func WithCalldata(calldata []byte) EmulatorOpt {
+	return func(emulator *Emulator) {
+		emulator.calldata = calldata
+	}
+}
+
+func NewEmulator(d Disassembler, opts ...EmulatorOpt) Emulator {
+	e := Emulator{
+		Disassembler: d,
+		maxSteps:     defaultMaxSteps,
+		maxVisits:    defaultMaxVisits,
+	}
+	for _, opt := range opts {
+		opt(&e)
+	}
+	return e
+}
+
+// Run explores the bytecode from the first instruction with an empty stack
+func (e Emulator) Run(hook InstructionHook) {
+	e.RunFrom(0, nil, hook)
+}
+
+// RunFrom explores the bytecode from the instruction at pc with the given initial stack
+func (e Emulator) RunFrom(pc uint64, stack []StackValue, hook InstructionHook) {
+	start, ok := e.InstructionIndex(pc)
+	if !ok {
+		return
+	}
+	initial := &ExecutionState{Stack: stack, index: start}
+	if e.trackMemory {
+		initial.memory = newMemory()
+	}
+	pending := []*ExecutionState{initial.fork(start)}
+	visited := make(map[string]bool)
+	visits := make(map[uint64]int)
+	steps := 0
+	for len(pending) > 0 && steps < e.maxSteps {
+		state := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		blockPC := e.Instructions[state.index].PC
+		key := state.key(blockPC)
+		if visited[key] || visits[blockPC] >= e.maxVisits {
+			continue
+		}
+		visited[key] = true
+		visits[blockPC]++
+
+		pending = append(pending, e.runBlock(state, hook, &steps)...)
+	}
+}
+
+// runBlock executes a single basic block and returns the states at the start of the successor blocks
+func (e Emulator) runBlock(state *ExecutionState, hook InstructionHook, steps *int) []*ExecutionState {
+	blockStart := state.index
+	for state.index < len(e.Instructions) && *steps < e.maxSteps {
+		inst := e.Instructions[state.index]
+		// Falling through into a JUMPDEST starts a new block
+		if inst.Op == vm.JUMPDEST && state.index != blockStart {
+			return []*ExecutionState{state}
+		}
+		if hook != nil && !hook(inst, state) {
+			return nil
+		}
+		*steps++
+
+		switch inst.Op {
+		case vm.JUMP:
+			dest := state.pop()
+			if next, ok := e.jumpTarget(dest); ok {
+				state.index = next
+				return []*ExecutionState{state}
+			}
+			return nil
+		case vm.JUMPI:
+			dest, cond := state.pop(), state.pop()
+			next := make([]*ExecutionState, 0, 2)
+			if cond.IsKnown() {
+				if cond.Value.Sign() == 0 {
+					state.index++
+					return append(next, state)
+				}
+				if target, ok := e.jumpTarget(dest); ok {
+					state.index = target
+					return append(next, state)
+				}
+				return nil
+			}
+			if target, ok := e.jumpTarget(dest); ok {
+				next = append(next, state.fork(target))
+			}
+			state.index++
+			return append(next, state)
+		case vm.STOP, vm.RETURN, vm.REVERT, vm.INVALID, vm.SELFDESTRUCT:
+			return nil
+		}
+
+		if !e.step(inst, state) {
+			return nil
+		}
+		state.index++
+	}
+	return nil
+}
+
+func (e Emulator) jumpTarget(dest StackValue) (int, bool) {
+	if !dest.IsKnown() || !dest.Value.IsUint64() {
+		return 0, false
+	}
+	index, ok := e.JumpDestinations[dest.Value.Uint64()]
+	return index, ok
+}
+
+// step applies the stack effect of a non control flow instruction, returns false when the instruction is
+// undefined or the stack overflows
+func (e Emulator) step(inst Instruction, state *ExecutionState) bool {
+	op := inst.Op
+	switch {
+	case op == vm.PUSH0:
+		state.push(StackValue{Value: new(big.Int), PC: inst.PC, Op: op})
+		return len(state.Stack) <= maxStackDepth
+	case op.IsPush():
+		state.push(StackValue{Value: new(big.Int).SetBytes(inst.Arg), PC: inst.PC, Op: op})
+		return len(state.Stack) <= maxStackDepth
+	case op >= vm.DUP1 && op <= vm.DUP16:
+		state.push(state.Peek(int(op - vm.DUP1)))
+		return len(state.Stack) <= maxStackDepth
+	case op >= vm.SWAP1 && op <= vm.SWAP16:
+		n := int(op-vm.SWAP1) + 1
+		for len(state.Stack) <= n {
+			// Pad the stack with unknown values on underflow
+			state.Stack = append([]StackValue{{}}, state.Stack...)
+		}
+		top := len(state.Stack) - 1
+		state.Stack[top], state.Stack[top-n] = state.Stack[top-n], state.Stack[top]
+		return true
+	}
+
+	pops, pushes, ok := stackEffect(op)
+	if !ok {
+		return false
+	}
+	args := make([]StackValue, pops)
+	for i := range args {
+		args[i] = state.pop()
+	}
+	if state.memory != nil {
+		e.writeMemory(op, args, state)
+	}
+	if pushes == 0 {
+		return true
+	}
+	res := StackValue{PC: inst.PC, Op: op}
+	switch op {
+	case vm.PC:
+		res.Value = new(big.Int).SetUint64(inst.PC)
+	case vm.CODESIZE:
+		res.Value = big.NewInt(int64(len(e.OriginalByteCode)))
+	case vm.CALLDATALOAD:
+		if word, ok := e.readCalldata(args[0], 32); ok {
+			res.Value = new(big.Int).SetBytes(word)
+		}
+		res.FromCalldata = true
+	case vm.MLOAD:
+		if state.memory != nil {
+			res.Value = e.readMemoryWord(args[0], state)
+		}
+	default:
+		res.Value = evaluate(op, args)
+	}
+	for _, arg := range args {
+		res.FromCalldata = res.FromCalldata || arg.FromCalldata
+	}
+	if !res.IsKnown() {
+		res.Args = args
+	}
+	state.push(res)
+	return len(state.Stack) <= maxStackDepth
+}
+
+// evaluate computes the result of an arithmetic or bitwise operation when all the operands are known
+func evaluate(op vm.OpCode, args []StackValue) *big.Int {
+	for _, arg := range args {
+		if !arg.IsKnown() {
+			return nil
+		}
+	}
+	switch op {
+	case vm.ADD:
+		return new(big.Int).Mod(new(big.Int).Add(args[0].Value, args[1].Value), tt256)
+	case vm.SUB:
+		return new(big.Int).Mod(new(big.Int).Sub(args[0].Value, args[1].Value), tt256)
+	case vm.MUL:
+		return new(big.Int).Mod(new(big.Int).Mul(args[0].Value, args[1].Value), tt256)
+	case vm.DIV:
+		if args[1].Value.Sign() == 0 {
+			return new(big.Int)
+		}
+		return new(big.Int).Div(args[0].Value, args[1].Value)
+	case vm.MOD:
+		if args[1].Value.Sign() == 0 {
+			return new(big.Int)
+		}
+		return new(big.Int).Mod(args[0].Value, args[1].Value)
+	case vm.EXP:
+		return new(big.Int).Exp(args[0].Value, args[1].Value, tt256)
+	case vm.LT:
+		return boolValue(args[0].Value.Cmp(args[1].Value) < 0)
+	case vm.GT:
+		return boolValue(args[0].Value.Cmp(args[1].Value) > 0)
+	case vm.EQ:
+		return boolValue(args[0].Value.Cmp(args[1].Value) == 0)
+	case vm.ISZERO:
+		return boolValue(args[0].Value.Sign() == 0)
+	case vm.AND:
+		return new(big.Int).And(args[0].Value, args[1].Value)
+	case vm.OR:
+		return new(big.Int).Or(args[0].Value, args[1].Value)
+	case vm.XOR:
+		return new(big.Int).Xor(args[0].Value, args[1].Value)
+	case vm.NOT:
+		return new(big.Int).Xor(args[0].Value, tt256m1)
+	case vm.SHL:
+		if args[0].Value.Cmp(big256) >= 0 {
+			return new(big.Int)
+		}
+		return new(big.Int).And(new(big.Int).Lsh(args[1].Value, uint(args[0].Value.Uint64())), tt256m1)
+	case vm.SHR:
+		if args[0].Value.Cmp(big256) >= 0 {
+			return new(big.Int)
+		}
+		return new(big.Int).Rsh(args[1].Value, uint(args[0].Value.Uint64()))
+	}
+	return nil
+}
+
+func boolValue(b bool) *big.Int {
+	if b {
+		return big.NewInt(1)
+	}
+	return new(big.Int)
+}
+
+// stackEffect returns the number of items popped and pushed by op, ok is false for undefined opcodes
+func stackEffect(op vm.OpCode) (pops int, pushes int, ok bool) {
+	switch {
+	case op >= vm.LOG0 && op <= vm.LOG4:
+		return int(op-vm.LOG0) + 2, 0, true
+	}
+	switch op {
+	case vm.STOP, vm.JUMPDEST, vm.INVALID:
+		return 0, 0, true
+	case vm.ADD, vm.MUL, vm.SUB, vm.DIV, vm.SDIV, vm.MOD, vm.SMOD, vm.EXP, vm.SIGNEXTEND,
+		vm.LT, vm.GT, vm.SLT, vm.SGT, vm.EQ, vm.AND, vm.OR, vm.XOR, vm.BYTE, vm.SHL, vm.SHR, vm.SAR,
+		vm.KECCAK256:
+		return 2, 1, true
+	case vm.ADDMOD, vm.MULMOD:
+		return 3, 1, true
+	case vm.ISZERO, vm.NOT, vm.BALANCE, vm.CALLDATALOAD, vm.EXTCODESIZE, vm.EXTCODEHASH, vm.BLOCKHASH,
+		vm.MLOAD, vm.SLOAD:
+		return 1, 1, true
+	case vm.ADDRESS, vm.ORIGIN, vm.CALLER, vm.CALLVALUE, vm.CALLDATASIZE, vm.CODESIZE, vm.GASPRICE,
+		vm.RETURNDATASIZE, vm.COINBASE, vm.TIMESTAMP, vm.NUMBER, vm.DIFFICULTY, vm.GASLIMIT, vm.CHAINID,
+		vm.SELFBALANCE, vm.BASEFEE, vm.PC, vm.MSIZE, vm.GAS:
+		return 0, 1, true
+	case vm.CALLDATACOPY, vm.CODECOPY, vm.RETURNDATACOPY:
+		return 3, 0, true
+	case vm.EXTCODECOPY:
+		return 4, 0, true
+	case vm.POP, vm.JUMP, vm.SELFDESTRUCT:
+		return 1, 0, true
+	case vm.MSTORE, vm.MSTORE8, vm.SSTORE, vm.JUMPI, vm.RETURN, vm.REVERT:
+		return 2, 0, true
+	case vm.CREATE:
+		return 3, 1, true
+	case vm.CREATE2:
+		return 4, 1, true
+	case vm.CALL, vm.CALLCODE:
+		return 7, 1, true
+	case vm.DELEGATECALL, vm.STATICCALL:
+		return 6, 1, true
+	}
+	return 0, 0, false
+}
+
+// EventLog is a LOG instruction whose topic0 could be resolved to a constant
+type EventLog struct {
+	PC uint64
+	// Topic0 is the event signature prefixed with 0x
+	Topic0 string
+	// IndexedTopics is the number of topics following topic0
+	IndexedTopics int
+}
+
+// EventLogs explores the bytecode and reports the constant passed as topic0 at every reachable LOG1-LOG4
+func (e Emulator) EventLogs() []EventLog {
+	return e.EventLogsFrom(0)
+}
+
+// EventLogsFrom is EventLogs exploring from each of the given PCs with an empty stack, for e.g. function entries
+// which cannot be reached from the start of the code by emulation
+func (e Emulator) EventLogsFrom(pcs ...uint64) []EventLog {
+	logs := make([]EventLog, 0)
+	seen := make(map[string]bool)
+	hook := func(inst Instruction, state *ExecutionState) bool {
+		if inst.Op < vm.LOG1 || inst.Op > vm.LOG4 {
+			return true
+		}
+		// LOGn pops offset, size, topic0 ... topic(n-1)
+		topic0 := state.Peek(2)
+		if !topic0.IsKnown() {
+			return true
+		}
+		log := EventLog{
+			PC:            inst.PC,
+			Topic0:        hexutil.Encode(common.BigToHash(topic0.Value).Bytes()),
+			IndexedTopics: int(inst.Op - vm.LOG1),
+		}
+		key := fmt.Sprintf("%d:%s", log.PC, log.Topic0)
+		if !seen[key] {
+			seen[key] = true
+			logs = append(logs, log)
+		}
+		return true
+	}
+	for _, pc := range pcs {
+		e.RunFrom(pc, nil, hook)
+	}
+	return logs
+}
@@ -0,0 +1,178 @@
+package asm
+
+import (
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+)
+
+const (
+	// maxTrackedMemory bounds the memory offsets and copy sizes which are tracked byte by byte
+	maxTrackedMemory = 1 << 20
+)
+
+// memory holds the EVM memory as far as it is known statically. Memory starts zeroed, bytes written from unknown
+// values are marked unknown and a write to an unknown region makes every byte not written afterwards unknown
+type memory struct {
+	known      map[uint64]byte
+	unknown    map[uint64]bool
+	allUnknown bool
+}
+
+func newMemory() *memory {
+	return &memory{known: make(map[uint64]byte), unknown: make(map[uint64]bool)}
+}
+
+func (m *memory) copy() *memory {
+	if m == nil {
+		return nil
+	}
+	res := &memory{
+		known:      make(map[uint64]byte, len(m.known)),
+		unknown:    make(map[uint64]bool, len(m.unknown)),
+		allUnknown: m.allUnknown,
+	}
+	for k, v := range m.known {
+		res.known[k] = v
+	}
+	for k := range m.unknown {
+		res.unknown[k] = true
+	}
+	return res
+}
+
+func (m *memory) store(offset uint64, data []byte) {
+	for i, b := range data {
+		m.known[offset+uint64(i)] = b
+		delete(m.unknown, offset+uint64(i))
+	}
+}
+
+func (m *memory) forget(offset uint64, size uint64) {
+	for i := uint64(0); i < size; i++ {
+		delete(m.known, offset+i)
+		m.unknown[offset+i] = true
+	}
+}
+
+func (m *memory) clear() {
+	m.known = make(map[uint64]byte)
+	m.unknown = make(map[uint64]bool)
+	m.allUnknown = true
+}
+
+func (m *memory) load(offset uint64, size uint64) ([]byte, bool) {
+	res := make([]byte, size)
+	for i := range res {
+		addr := offset + uint64(i)
+		if b, ok := m.known[addr]; ok {
+			res[i] = b
+		} else if m.allUnknown || m.unknown[addr] {
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+// region returns the offset and size of a memory range when both are known and small enough to be tracked
+func region(offset StackValue, size StackValue) (uint64, uint64, bool) {
+	if !offset.IsKnown() || !size.IsKnown() || !offset.Value.IsUint64() || !size.Value.IsUint64() {
+		return 0, 0, false
+	}
+	if offset.Value.Uint64() > maxTrackedMemory || size.Value.Uint64() > maxTrackedMemory {
+		return 0, 0, false
+	}
+	return offset.Value.Uint64(), size.Value.Uint64(), true
+}
+
+// writeMemory applies the memory writes of op, args being the popped operands. Writes to unknown regions
+// invalidate the whole memory
+func (e Emulator) writeMemory(op vm.OpCode, args []StackValue, state *ExecutionState) {
+	var (
+		offset, size StackValue
+		data         func(offset uint64, size uint64) ([]byte, bool)
+	)
+	switch op {
+	case vm.MSTORE, vm.MSTORE8:
+		offset, size = args[0], StackValue{Value: big.NewInt(32)}
+		if op == vm.MSTORE8 {
+			size = StackValue{Value: big.NewInt(1)}
+		}
+		data = func(_ uint64, size uint64) ([]byte, bool) {
+			if !args[1].IsKnown() {
+				return nil, false
+			}
+			word := args[1].Value.FillBytes(make([]byte, 32))
+			return word[32-size:], true
+		}
+	case vm.CODECOPY:
+		offset, size = args[0], args[2]
+		data = func(_ uint64, size uint64) ([]byte, bool) {
+			return e.readCode(args[1], size)
+		}
+	case vm.CALLDATACOPY:
+		offset, size = args[0], args[2]
+		data = func(_ uint64, size uint64) ([]byte, bool) {
+			return e.readCalldata(args[1], size)
+		}
+	case vm.RETURNDATACOPY:
+		offset, size = args[0], args[2]
+	case vm.EXTCODECOPY:
+		offset, size = args[1], args[3]
+	case vm.CALL, vm.CALLCODE:
+		offset, size = args[5], args[6]
+	case vm.DELEGATECALL, vm.STATICCALL:
+		offset, size = args[4], args[5]
+	default:
+		return
+	}
+	start, length, ok := region(offset, size)
+	if !ok {
+		state.memory.clear()
+		return
+	}
+	if data != nil {
+		if bytes, ok := data(start, length); ok {
+			state.memory.store(start, bytes)
+			return
+		}
+	}
+	state.memory.forget(start, length)
+}
+
+// readMemoryWord returns the word at offset when all of its bytes are known
+func (e Emulator) readMemoryWord(offset StackValue, state *ExecutionState) *big.Int {
+	start, _, ok := region(offset, StackValue{Value: big.NewInt(32)})
+	if !ok {
+		return nil
+	}
+	word, ok := state.memory.load(start, 32)
+	if !ok {
+		return nil
+	}
+	return new(big.Int).SetBytes(word)
+}
+
+// readCode returns size bytes of the bytecode from offset, zero padded past the end of the code
+func (e Emulator) readCode(offset StackValue, size uint64) ([]byte, bool) {
+	return readPadded(e.OriginalByteCode, offset, size)
+}
+
+// readCalldata returns size bytes of the calldata from offset when the calldata is provided
+func (e Emulator) readCalldata(offset StackValue, size uint64) ([]byte, bool) {
+	if e.calldata == nil {
+		return nil, false
+	}
+	return readPadded(e.calldata, offset, size)
+}
+
+func readPadded(src []byte, offset StackValue, size uint64) ([]byte, bool) {
+	if !offset.IsKnown() || !offset.Value.IsUint64() {
+		return nil, false
+	}
+	res := make([]byte, size)
+	start := offset.Value.Uint64()
+	if start < uint64(len(src)) {
+		copy(res, src[start:])
+	}
+	return res, true
+}
@@ -0,0 +1,54 @@
+package asm
+
+import (
+	"testing"
+)
+
+func TestEmulator_EventLogs(t *testing.T) {
+	tests := []struct {
+		name              string
+		code              string
+		wantIndexedTopics map[string]int
+	}{
+		{
+			name: "Indexed topics from LOG instructions - Simple Token 2",
+			code: simpleTokenBytecode2,
+			wantIndexedTopics: map[string]int{
+				"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef": 2,
+				"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925": 2,
+				"0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0": 2,
+				"0x16a1412f01b73c390eb2548427101644aa86c1443c272f73df00fb74c48fe499": 3,
+			},
+		},
+		{
+			name: "Topic loaded through DUP/SWAP chain - USDT",
+			code: usdTBytecode,
+			wantIndexedTopics: map[string]int{
+				"0xcb8241adb0c3fdb35b70c24ce35c5eb0c17af7431c99f827d44a445ca624176a": 0,
+				"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef": 2,
+			},
+		},
+		{
+			name:              "PUSH32 compared against a hash is not an event",
+			code:              "7f" + "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" + "600035" + "14" + "602957" + "00" + "5b" + "600035" + "60006000a1" + "00",
+			wantIndexedTopics: map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewEventSignsFromLogs(NewEmulator(NewTestDisassembler(tt.code)).EventLogs())
+			if len(tt.wantIndexedTopics) == 0 && len(got.Signatures) != 0 {
+				t.Errorf("EventLogs() wanted no events, got = %v", got.List())
+			}
+			for sign, indexed := range tt.wantIndexedTopics {
+				if !got.Signatures[sign] {
+					t.Errorf("EventLogs() wanted = %v but not found", sign)
+					continue
+				}
+				if got.IndexedTopics[sign] != indexed {
+					t.Errorf("EventLogs() indexed topics for %v = %v, want %v", sign, got.IndexedTopics[sign], indexed)
+				}
+			}
+		})
+	}
+}
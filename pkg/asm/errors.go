@@ -0,0 +1,56 @@
+package asm
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+)
+
+const (
+	// errorStringSelector is Error(string), used by require and revert with a reason string
+	errorStringSelector = "0x08c379a0"
+	// panicSelector is Panic(uint256), used by assert and the checked arithmetic of Solidity 0.8
+	panicSelector = "0x4e487b71"
+)
+
+// RevertSelectorsFrom explores the bytecode from each of the given PCs with an empty stack and reports the selectors
+// of the custom errors at every reachable REVERT. The revert data is expected to start with a selector written to
+// memory, which requires the emulator to track memory
+//
+//	PUSH4 <BYTE4> PUSH1 0xe0 SHL DUP2 MSTORE ... REVERT
+//
+// The builtin Error(string) and Panic(uint256) are not custom errors and are not reported
+//
+//	Ref: https://docs.soliditylang.org/en/latest/contracts.html#errors-and-the-revert-statement
+func (e Emulator) RevertSelectorsFrom(pcs ...uint64) []string {
+	selectors := make([]string, 0)
+	seen := map[string]bool{errorStringSelector: true, panicSelector: true}
+	hook := func(inst Instruction, state *ExecutionState) bool {
+		if inst.Op != vm.REVERT || state.memory == nil {
+			return true
+		}
+		// REVERT pops offset, size
+		offset, size := state.Peek(0), state.Peek(1)
+		if size.IsKnown() && size.Value.Cmp(big.NewInt(4)) < 0 {
+			return true
+		}
+		start, _, ok := region(offset, StackValue{Value: big.NewInt(4)})
+		if !ok {
+			return true
+		}
+		selector, ok := state.memory.load(start, 4)
+		if !ok || new(big.Int).SetBytes(selector).Sign() == 0 {
+			return true
+		}
+		sign := hexutil.Encode(selector)
+		if !seen[sign] {
+			seen[sign] = true
+			selectors = append(selectors, sign)
+		}
+		return true
+	}
+	for _, pc := range pcs {
+		e.RunFrom(pc, nil, hook)
+	}
+	return selectors
+}
@@ -0,0 +1,57 @@
+package asm
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// customErrorsBytecode is a hand assembled contract with two functions
+//
+//	0xaaaaaaaa  reverts with InsufficientBalance(uint256,uint256) written at the free memory pointer, or Error(string)
+//	0xbbbbbbbb  calls transfer(address,uint256) on another contract, then reverts with Unauthorized() written at 0
+const customErrorsBytecode = "6080604052348015600e575f80fd5b5060003560e01c8063aaaaaaaa14602d578063bbbbbbbb146068575f80fd5b6004" +
+	"3515604d5760405163cf47918160e01b815260040160405180910390fd5b602435156066576040516308c379a060e01b8152606490fd5b005b" +
+	"63a9059cbb60e01b5f526000600060446000600060115af1506044356096576382b4290060e01b5f5260045ffd5b00"
+
+// newErrorsBytecode is the runtime of the NewErrors contract of the go-ethereum binding tests compiled with solc 0.8.7,
+// Error() reverts with MyError3(uint256,uint256,uint256). The optimizer pushes the selector shifted right by 2 bits and
+// shifts it left by 230
+const newErrorsBytecode = "6080604052348015600f57600080fd5b506004361060285760003560e01c8063726c638214602d575b600080fd5b60336035565b005b60405163024876cd60e61b815260016004820152600260248201526003604482015260640160405180910390fdfea264697066735822122093f786a1bc60216540cd999fbb4a6109e0fef20abcff6e9107fb2817ca968f3c64736f6c63430008070033"
+
+func TestSolidityParser_GetErrorSigns(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []string
+	}{
+		{
+			name: "Custom errors",
+			code: customErrorsBytecode,
+			want: []string{"0x82b42900", "0xcf479181"},
+		},
+		{
+			name: "Custom error compiled by solc 0.8.7",
+			code: newErrorsBytecode,
+			want: []string{"0x921db340"},
+		},
+		{
+			name: "Only Error(string) and Panic(uint256) - Simple Token 2",
+			code: simpleTokenBytecode2,
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewSolidityParserStr(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := p.GetErrorSigns().List()
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetErrorSigns() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package asm
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+	"sort"
+)
+
+const (
+	// SupportsInterfaceSelector is supportsInterface(bytes4) of ERC-165
+	SupportsInterfaceSelector = "0x01ffc9a7"
+	// interfacesMaxSteps bounds the emulation of the supportsInterface body
+	interfacesMaxSteps = 100_000
+	// invalidInterfaceID must not be supported by any contract implementing ERC-165
+	invalidInterfaceID = "0xffffffff"
+)
+
+// InterfaceIDs returns the interface IDs prefixed with 0x which the supportsInterface(bytes4) body compares its
+// argument against, sorted. The body is the part of the CFG reachable from the function entry, including the
+// internal functions called by it, for e.g. the super.supportsInterface chain of OpenZeppelin
+//
+//	<interfaceId> PUSH4 <BYTE4> PUSH1 0xe0 SHL EQ
+//	<interfaceId> PUSH32 <BYTE4 followed by 28 zero bytes> EQ
+//
+// Returns an empty list when the contract does not implement supportsInterface
+//
+//	Ref: https://eips.ethereum.org/EIPS/eip-165
+func InterfaceIDs(d Disassembler, entries map[string]uint64) []string {
+	res := make([]string, 0)
+	entry, ok := entries[SupportsInterfaceSelector]
+	if !ok {
+		return res
+	}
+	e := NewEmulator(d, WithMaxSteps(interfacesMaxSteps))
+	cfg := NewCFG(d)
+	cfg.ResolveDynamicJumpsFrom(e, entry)
+	body := make(map[uint64]bool)
+	for _, block := range cfg.Reachable(entry) {
+		for _, inst := range block.Instructions {
+			body[inst.PC] = true
+		}
+	}
+
+	seen := map[string]bool{invalidInterfaceID: true}
+	e.RunFrom(entry, nil, func(inst Instruction, state *ExecutionState) bool {
+		if !body[inst.PC] {
+			return false
+		}
+		if inst.Op != vm.EQ {
+			return true
+		}
+		a, b := state.Peek(0), state.Peek(1)
+		for _, pair := range [][2]StackValue{{a, b}, {b, a}} {
+			id, ok := interfaceID(pair[0])
+			if !ok || !pair[1].FromCalldata || seen[id] {
+				continue
+			}
+			seen[id] = true
+			res = append(res, id)
+		}
+		return true
+	})
+	sort.Strings(res)
+	return res
+}
+
+// interfaceID reads a constant compared with the bytes4 argument, either right aligned or left aligned as bytes4
+// values are kept on the stack
+func interfaceID(v StackValue) (string, bool) {
+	if !v.IsKnown() || v.Value.Sign() == 0 {
+		return "", false
+	}
+	if v.Value.BitLen() <= 32 {
+		return fmt.Sprintf("0x%08x", v.Value), true
+	}
+	if v.Value.TrailingZeroBits() < 224 {
+		return "", false
+	}
+	return fmt.Sprintf("0x%08x", new(big.Int).Rsh(v.Value, 224)), true
+}
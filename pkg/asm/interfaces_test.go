@@ -0,0 +1,71 @@
+package asm
+
+import (
+	"reflect"
+	"testing"
+)
+
+// supportsInterfaceBytecode is a hand assembled contract with only supportsInterface(bytes4), which compares the
+// argument with ERC721 as PUSH4 SHL and ERC721Metadata as PUSH32, rejects 0xffffffff and calls an internal function
+// comparing with ERC165
+const supportsInterfaceBytecode = "608060405260003560e01c806301ffc9a7146018575f80fd5b6004357fffffffff00000000000000000000000000" +
+	"00000000000000000000000000000016806380ac58cd60e01b1460a457807f5b5e139f00000000000000000000000000000000000000000000" +
+	"0000000000001460a457807fffffffff000000000000000000000000000000000000000000000000000000001460ad57609d9060b5565b5f52" +
+	"60205ff35b60015f5260205ff35b5f5f5260205ff35b6301ffc9a760e01b149056"
+
+// erc721SupportsInterfaceBytecode is hand assembled after the solc 0.8 optimised runtime of an OpenZeppelin 4.x ERC721,
+// reduced to supportsInterface and isApprovedForAll. supportsInterface decodes the argument with the bytes4 validator,
+// compares it with ERC721 and ERC721Metadata as masked PUSH4 SHL constants and calls the ERC165 super function
+const erc721SupportsInterfaceBytecode = "608060405234801561001057600080fd5b50600436106100365760003560e01c806301ffc9" +
+	"a71461003b578063e985e9c5146100ef575b600080fd5b61004e6100493660046100be565b610062565b604051901515815260200160405180" +
+	"910390f35b60006001600160e01b031982166380ac58cd60e01b148061009357506001600160e01b03198216635b5e139f60e01b145b806100" +
+	"a257506100a2826100a8565b92915050565b6001600160e01b0319166301ffc9a760e01b1490565b6000602082840312156100d057600080fd" +
+	"5b81356001600160e01b0319811681146100e857600080fd5b9392505050565b61004e6100fd366004610130565b610102565b6001600160a0" +
+	"1b03918216600090815260056020908152604080832093909416825291909152205460ff1690565b6000806040838503121561014357600080" +
+	"fd5b823561014e81610167565b9150602083013561015e81610167565b90509250929050565b6001600160a01b038116811461017c57600080" +
+	"fd5b5056"
+
+// erc1155SupportsInterfaceBytecode is hand assembled after the via-IR optimised supportsInterface of an OpenZeppelin
+// ERC1155 with AccessControl: the argument is shifted right and compared as PUSH4 DUP2 EQ, the ERC1155,
+// ERC1155MetadataURI and ERC165 comparisons are combined with OR
+const erc1155SupportsInterfaceBytecode = "60806040526004361015610013575b600080fd5b60003560e01c6301ffc9a714610028576" +
+	"1000e565b3461000e57602060031936011261000e576004356001600160e01b0319811680910361000e5760e01c637965db0b8114806100765" +
+	"75063d9b67a268114630e89341c8214176301ffc9a78214175b15156080525060206080f3"
+
+func TestInterfaceIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []string
+	}{
+		{
+			name: "supportsInterface with an internal call",
+			code: supportsInterfaceBytecode,
+			want: []string{"0x01ffc9a7", "0x5b5e139f", "0x80ac58cd"},
+		},
+		{
+			name: "ERC721 with the super call to ERC165",
+			code: erc721SupportsInterfaceBytecode,
+			want: []string{"0x01ffc9a7", "0x5b5e139f", "0x80ac58cd"},
+		},
+		{
+			name: "ERC1155 with AccessControl and an OR chain",
+			code: erc1155SupportsInterfaceBytecode,
+			want: []string{"0x01ffc9a7", "0x0e89341c", "0x7965db0b", "0xd9b67a26"},
+		},
+		{
+			name: "No supportsInterface - Simple Token 2",
+			code: simpleTokenBytecode2,
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewTestDisassembler(tt.code)
+			got := InterfaceIDs(d, FindDispatchEntries(d))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InterfaceIDs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package asm
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// maxCborDepth bounds the nesting of the decoded CBOR items
+	maxCborDepth = 8
+)
+
+var (
+	ErrNoMetadata      = errors.New("no metadata trailer found")
+	errCborUnsupported = errors.New("unsupported cbor item")
+	errCborTruncated   = errors.New("truncated cbor item")
+)
+
+// Metadata is the CBOR blob the compiler appends to the runtime bytecode, followed by its length in 2 bytes
+//
+//	Ref: https://docs.soliditylang.org/en/latest/metadata.html#encoding-of-the-metadata-hash-in-the-bytecode
+type Metadata struct {
+	Compiler Compiler
+	// Version of the compiler, for e.g. 0.8.17. Empty for solc < 0.5.9 which did not include it
+	Version string
+	// SourceHashType is ipfs, bzzr0 or bzzr1 for Solidity, SourceHash is the hash of the metadata JSON
+	SourceHashType string
+	SourceHash     []byte
+	Experimental   bool
+	// Length of the trailer in bytes including the 2 length bytes
+	Length int
+}
+
+// SourceURL returns the location of the metadata JSON, for e.g. ipfs://Qm..., empty when there is no hash
+func (m Metadata) SourceURL() string {
+	switch {
+	case len(m.SourceHash) == 0:
+		return ""
+	case m.SourceHashType == "ipfs":
+		return "ipfs://" + base58Encode(m.SourceHash)
+	}
+	return "bzz-raw://" + hex.EncodeToString(m.SourceHash)
+}
+
+// ParseMetadata decodes the metadata trailer at the end of the runtime bytecode. Solidity appends a CBOR map,
+// Vyper a map {"vyper": [major, minor, patch]} or, since 0.3.10, an array ending with that map
+func ParseMetadata(code []byte) (Metadata, error) {
+	if len(code) < 2 {
+		return Metadata{}, ErrNoMetadata
+	}
+	size := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	// Vyper >= 0.4 includes the 2 length bytes in the length
+	for _, cborSize := range []int{size, size - 2} {
+		if cborSize <= 0 || cborSize+2 > len(code) {
+			continue
+		}
+		item, err := decodeCbor(code[len(code)-2-cborSize : len(code)-2])
+		if err != nil {
+			continue
+		}
+		if m, ok := metadataFromCbor(item); ok {
+			m.Length = cborSize + 2
+			return m, nil
+		}
+	}
+	return Metadata{}, ErrNoMetadata
+}
+
+func metadataFromCbor(item interface{}) (Metadata, bool) {
+	if items, ok := item.([]interface{}); ok && len(items) > 0 {
+		item = items[len(items)-1]
+	}
+	fields, ok := item.(map[string]interface{})
+	if !ok {
+		return Metadata{}, false
+	}
+	if version, ok := fields["vyper"].([]interface{}); ok {
+		return Metadata{Compiler: Vyper, Version: joinVersion(version)}, true
+	}
+	m := Metadata{Compiler: Solidity}
+	for _, hashType := range []string{"ipfs", "bzzr0", "bzzr1"} {
+		if hash, ok := fields[hashType].([]byte); ok {
+			m.SourceHashType, m.SourceHash = hashType, hash
+		}
+	}
+	switch version := fields["solc"].(type) {
+	case []byte:
+		parts := make([]interface{}, 0, len(version))
+		for _, b := range version {
+			parts = append(parts, uint64(b))
+		}
+		m.Version = joinVersion(parts)
+	case string:
+		// Pre-release builds store the full version string
+		m.Version = version
+	}
+	m.Experimental, _ = fields["experimental"].(bool)
+	return m, m.SourceHashType != "" || m.Version != ""
+}
+
+func joinVersion(parts []interface{}) string {
+	res := make([]string, 0, len(parts))
+	for _, part := range parts {
+		res = append(res, fmt.Sprint(part))
+	}
+	return strings.Join(res, ".")
+}
+
+// decodeCbor decodes a single CBOR item spanning the whole of data. Only the definite length items used by the
+// compilers are supported: integers, byte and text strings, arrays, maps with text keys, booleans and null
+func decodeCbor(data []byte) (interface{}, error) {
+	item, rest, err := decodeCborItem(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after cbor item", len(rest))
+	}
+	return item, nil
+}
+
+func decodeCborItem(data []byte, depth int) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errCborTruncated
+	}
+	if depth > maxCborDepth {
+		return nil, nil, errCborUnsupported
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, data[1:], nil
+		case 21:
+			return true, data[1:], nil
+		case 22:
+			return nil, data[1:], nil
+		}
+		return nil, nil, errCborUnsupported
+	}
+	arg, data, err := decodeCborArgument(info, data[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+	switch major {
+	case 0:
+		return arg, data, nil
+	case 1:
+		return new(big.Int).Neg(new(big.Int).SetUint64(arg + 1)), data, nil
+	case 2, 3:
+		if arg > uint64(len(data)) {
+			return nil, nil, errCborTruncated
+		}
+		if major == 2 {
+			return data[:arg], data[arg:], nil
+		}
+		return string(data[:arg]), data[arg:], nil
+	case 4:
+		items := make([]interface{}, 0)
+		for i := uint64(0); i < arg; i++ {
+			var item interface{}
+			if item, data, err = decodeCborItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, data, nil
+	case 5:
+		fields := make(map[string]interface{})
+		for i := uint64(0); i < arg; i++ {
+			var key, value interface{}
+			if key, data, err = decodeCborItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			if value, data, err = decodeCborItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return nil, nil, errCborUnsupported
+			}
+			fields[name] = value
+		}
+		return fields, data, nil
+	}
+	return nil, nil, errCborUnsupported
+}
+
+// decodeCborArgument decodes the length or value following the initial byte of an item
+func decodeCborArgument(info byte, data []byte) (uint64, []byte, error) {
+	if info < 24 {
+		return uint64(info), data, nil
+	}
+	if info > 27 {
+		// Indefinite lengths and reserved values
+		return 0, nil, errCborUnsupported
+	}
+	size := 1 << (info - 24)
+	if len(data) < size {
+		return 0, nil, errCborTruncated
+	}
+	var arg uint64
+	for _, b := range data[:size] {
+		arg = arg<<8 | uint64(b)
+	}
+	return arg, data[size:], nil
+}
+
+func base58Encode(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	res := make([]byte, 0, len(data)*138/100+1)
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		res = append(res, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		res = append(res, base58Alphabet[0])
+	}
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return string(res)
+}
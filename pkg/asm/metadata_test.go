@@ -0,0 +1,93 @@
+package asm
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    Metadata
+		wantURL string
+		wantErr error
+	}{
+		{
+			name: "Solidity ipfs - Simple Token 2",
+			code: simpleTokenBytecode2,
+			want: Metadata{Compiler: Solidity, Version: "0.8.0", SourceHashType: "ipfs", Length: 53},
+			// The hash is a multihash, prefixed with 0x1220
+			wantURL: "ipfs://QmULTXDD78yDAZ8GTDX78EQjXLM8Km8zQJ12ZY75oUPMB3",
+		},
+		{
+			name:    "Solidity bzzr0 without version - USDT",
+			code:    usdTBytecode,
+			want:    Metadata{Compiler: Solidity, SourceHashType: "bzzr0", Length: 43},
+			wantURL: "bzz-raw://645ee12d73db47fd78ba77fa1f824c3c8f9184061b3b10386beb4dc9236abb28",
+		},
+		{
+			name: "Solidity bzzr1 experimental",
+			code: "00a365627a7a72315820000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f6c6578706572696d656e74616c" +
+				"f564736f6c634300050c0040",
+			want:    Metadata{Compiler: Solidity, Version: "0.5.12", SourceHashType: "bzzr1", Experimental: true, Length: 66},
+			wantURL: "bzz-raw://000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		},
+		{
+			name: "Vyper map",
+			code: vyperLinearBytecode + "a165767970657283000307000b",
+			want: Metadata{Compiler: Vyper, Version: "0.3.7", Length: 13},
+		},
+		{
+			name: "Vyper 0.3.10 array",
+			code: vyperLinearBytecode + "841832810200a16576797065728300030a0011",
+			want: Metadata{Compiler: Vyper, Version: "0.3.10", Length: 19},
+		},
+		{
+			name: "Vyper 0.4 array with length including itself",
+			code: vyperLinearBytecode + "8418328000a1657679706572830004000012",
+			want: Metadata{Compiler: Vyper, Version: "0.4.0", Length: 18},
+		},
+		{
+			name:    "No metadata",
+			code:    vyperLinearBytecode,
+			wantErr: ErrNoMetadata,
+		},
+		{
+			name:    "Truncated trailer",
+			code:    "65767970657283000307000b",
+			wantErr: ErrNoMetadata,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := hex.DecodeString(tt.code)
+			got, err := ParseMetadata(code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Compiler != tt.want.Compiler || got.Version != tt.want.Version || got.Length != tt.want.Length ||
+				got.SourceHashType != tt.want.SourceHashType || got.Experimental != tt.want.Experimental {
+				t.Errorf("ParseMetadata() got = %+v, want %+v", got, tt.want)
+			}
+			if got.SourceURL() != tt.wantURL {
+				t.Errorf("SourceURL() got = %v, want %v", got.SourceURL(), tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestNewDisassembler_StripsMetadata(t *testing.T) {
+	d := NewTestDisassembler(simpleTokenBytecode2)
+	if d.Metadata == nil {
+		t.Fatal("NewDisassembler() metadata not found")
+	}
+	last := d.Instructions[len(d.Instructions)-1]
+	if int(last.PC)+1+len(last.Arg) != len(d.OriginalByteCode)-d.Metadata.Length {
+		t.Errorf("NewDisassembler() last instruction %v is in the metadata trailer", last)
+	}
+}
@@ -0,0 +1,135 @@
+package asm
+
+import (
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+)
+
+type StateMutability string
+
+const (
+	Pure       StateMutability = "pure"
+	View       StateMutability = "view"
+	NonPayable StateMutability = "nonpayable"
+	Payable    StateMutability = "payable"
+)
+
+const (
+	// mutabilityMaxSteps bounds the emulation of a single function body
+	mutabilityMaxSteps = 100_000
+	// guardWindow is the number of instructions searched for the CALLVALUE guard
+	guardWindow = 8
+)
+
+// InferStateMutability classifies the function body starting at entry. A function without the CALLVALUE guard is
+// payable, otherwise the instructions reachable from the entry decide between
+//
+//	nonpayable  SSTORE, LOG, CALL, CALLCODE, DELEGATECALL, CREATE, CREATE2 or SELFDESTRUCT
+//	view        SLOAD, STATICCALL or any of the environment reads not allowed in pure functions
+//	pure        none of the above
+//
+// guarded reports whether the CALLVALUE guard is shared by all the functions in the dispatcher. Functions which the
+// compiler cannot tell apart are reported with the weakest mutability, for e.g. a view returning a constant is pure
+//
+//	Ref: https://docs.soliditylang.org/en/latest/contracts.html#state-mutability
+func InferStateMutability(d Disassembler, entry uint64, guarded bool) StateMutability {
+	index, ok := d.InstructionIndex(entry)
+	if !ok {
+		return NonPayable
+	}
+	if !guarded && !d.hasCallValueGuard(index) {
+		return Payable
+	}
+	res := Pure
+	NewEmulator(d, WithMaxSteps(mutabilityMaxSteps)).RunFrom(entry, nil, func(inst Instruction, state *ExecutionState) bool {
+		switch {
+		case res == NonPayable:
+			return false
+		case writesState(inst.Op):
+			res = NonPayable
+			return false
+		case readsState(inst.Op):
+			res = View
+		}
+		return true
+	})
+	return res
+}
+
+// hasCallValueGuard matches the non-payable check in the instructions following index, the revert must be taken when
+// CALLVALUE is not zero. A payable function starting with require(msg.value > 0) reverts when it is zero instead
+//
+//	Solidity  CALLVALUE DUP1 ISZERO PUSH <continue> JUMPI PUSH 0 DUP1 REVERT
+//	Vyper     CALLVALUE PUSH <revert> JUMPI
+func (d Disassembler) hasCallValueGuard(index int) bool {
+	for i := index; i < len(d.Instructions) && i < index+guardWindow; i++ {
+		if d.Instructions[i].Op != vm.CALLVALUE {
+			continue
+		}
+		negated := false
+		var dest []byte
+		for j := i + 1; j < len(d.Instructions); j++ {
+			inst := d.Instructions[j]
+			switch {
+			case inst.Op == vm.JUMPI:
+				if dest == nil {
+					return false
+				}
+				// the jump is taken on a zero CALLVALUE when negated, the revert is then the fall through
+				if negated {
+					return d.isRevertBlock(j + 1)
+				}
+				target, ok := d.InstructionIndex(new(big.Int).SetBytes(dest).Uint64())
+				return ok && d.isRevertBlock(target)
+			case inst.Op == vm.ISZERO:
+				negated = !negated
+			case inst.Op.IsPush():
+				dest = inst.Arg
+			case inst.Op != vm.DUP1:
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// isRevertBlock matches the revert without data emitted by the compilers, starting at index
+//
+//	[JUMPDEST] PUSH 0 DUP1 REVERT
+//	[JUMPDEST] PUSH0 DUP1 REVERT
+//	[JUMPDEST] INVALID
+func (d Disassembler) isRevertBlock(index int) bool {
+	if index < len(d.Instructions) && d.Instructions[index].Op == vm.JUMPDEST {
+		index++
+	}
+	if index < len(d.Instructions) && d.Instructions[index].Op == vm.INVALID {
+		return true
+	}
+	if index+2 >= len(d.Instructions) {
+		return false
+	}
+	push := d.Instructions[index]
+	if push.Op != vm.PUSH0 && (!push.Op.IsPush() || new(big.Int).SetBytes(push.Arg).Sign() != 0) {
+		return false
+	}
+	return d.Instructions[index+1].Op == vm.DUP1 && d.Instructions[index+2].Op == vm.REVERT
+}
+
+func writesState(op vm.OpCode) bool {
+	switch op {
+	case vm.SSTORE, vm.LOG0, vm.LOG1, vm.LOG2, vm.LOG3, vm.LOG4, vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.CREATE,
+		vm.CREATE2, vm.SELFDESTRUCT:
+		return true
+	}
+	return false
+}
+
+func readsState(op vm.OpCode) bool {
+	switch op {
+	case vm.SLOAD, vm.STATICCALL, vm.BALANCE, vm.SELFBALANCE, vm.EXTCODESIZE, vm.EXTCODEHASH, vm.EXTCODECOPY,
+		vm.ADDRESS, vm.ORIGIN, vm.CALLER, vm.GASPRICE, vm.BLOCKHASH, vm.COINBASE, vm.TIMESTAMP, vm.NUMBER,
+		vm.DIFFICULTY, vm.GASLIMIT, vm.CHAINID, vm.BASEFEE, vm.GAS:
+		return true
+	}
+	return false
+}
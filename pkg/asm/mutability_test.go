@@ -0,0 +1,84 @@
+package asm
+
+import (
+	"testing"
+)
+
+// payableRequireBytecode is a hand assembled contract with two functions
+//
+//	0xaaaaaaaa  payable, starts with require(msg.value > 0): CALLVALUE ISZERO ISZERO PUSH <body> JUMPI PUSH 0 DUP1 REVERT
+//	0xbbbbbbbb  nonpayable, starts with the CALLVALUE guard and writes to storage
+const payableRequireBytecode = "6080604052600436106100295760003560e01c8063aaaaaaaa1461002e578063bbbbbbbb1461003c575b600080fd5b3415" +
+	"1561003a57600080fd5b005b34801561004857600080fd5b50600160005500"
+
+func TestAnalyseFunctions_StateMutability(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want map[string]StateMutability
+	}{
+		{
+			name: "Solidity 0.8 with the CALLVALUE guard before the dispatcher - Simple Token 2",
+			code: simpleTokenBytecode2,
+			want: map[string]StateMutability{
+				"0xa9059cbb": NonPayable,
+				"0x70a08231": View,
+				"0x18160ddd": View,
+				// PERMIT_TYPEHASH is a constant
+				"0x30adf81f": Pure,
+			},
+		},
+		{
+			name: "Solidity 0.4 with the CALLVALUE guard in every function - USDT",
+			code: usdTBytecode,
+			want: map[string]StateMutability{
+				"0xa9059cbb": NonPayable,
+				"0x06fdde03": View,
+				"0x5c975abb": View,
+				// MAX_UINT is a constant
+				"0xe5b5019a": Pure,
+			},
+		},
+		{
+			name: "Payable function requiring a non zero CALLVALUE",
+			code: payableRequireBytecode,
+			want: map[string]StateMutability{
+				"0xaaaaaaaa": Payable,
+				"0xbbbbbbbb": NonPayable,
+			},
+		},
+		{
+			name: "Vyper with a payable function",
+			code: vyperLinearBytecode,
+			want: map[string]StateMutability{
+				"0xa9059cbb": NonPayable,
+				"0x18160ddd": Payable,
+			},
+		},
+		{
+			name: "Vyper ERC20 with view getters",
+			code: vyperERC20LinearBytecode,
+			want: map[string]StateMutability{
+				"0x06fdde03": View,
+				"0x18160ddd": View,
+				"0x70a08231": View,
+				"0xdd62ed3e": View,
+				"0xa9059cbb": NonPayable,
+				"0x095ea7b3": NonPayable,
+				"0x40c10f19": NonPayable,
+				"0x42966c68": NonPayable,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewTestDisassembler(tt.code)
+			got := AnalyseFunctions(d, FindDispatchEntries(d))
+			for sign, want := range tt.want {
+				if got[sign].StateMutability != want {
+					t.Errorf("AnalyseFunctions() %s state mutability = %v, want %v", sign, got[sign].StateMutability, want)
+				}
+			}
+		})
+	}
+}
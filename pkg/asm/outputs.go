@@ -0,0 +1,160 @@
+package asm
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+)
+
+const (
+	// outputsMaxSteps bounds the emulation of a single function body
+	outputsMaxSteps = 100_000
+	// outputsMaxWords bounds the number of static words reported as outputs
+	outputsMaxWords = 32
+	// dynamicOutputType is reported for a return value with an offset/length header, bytes and arrays are encoded
+	// the same way
+	dynamicOutputType = "string"
+)
+
+var big32 = big.NewInt(32)
+
+// InferOutputs emulates the function body starting at entry and guesses the return types from the first RETURN whose
+// data can be read
+//
+//	RETURN(<ptr>, 32*n)                      n static words, typed from the value written to each of them
+//	RETURN(<ptr>, ?) with MSTORE(<ptr>, 32)  a single dynamic value, offset followed by the length and the data
+//
+// The return data is usually encoded at the free memory pointer, which is unknown once memory of unknown size is
+// allocated. Offsets are therefore compared symbolically as <unknown value> + <constant>, see linearForm. The result
+// is a guess and should be reported as unverified.
+// It is empty when the function returns nothing, i.e. it reaches STOP and no RETURN, and nil when the outputs are
+// unknown: no RETURN data could be read or the function never returns
+func InferOutputs(d Disassembler, entry uint64) []string {
+	var res []string
+	returned, stopped := false, false
+	// words are the values last written to each memory offset
+	words := make(map[linearForm]StackValue)
+	NewEmulator(d, WithMemory(), WithMaxSteps(outputsMaxSteps)).RunFrom(entry, nil, func(inst Instruction, state *ExecutionState) bool {
+		if res != nil {
+			return false
+		}
+		switch inst.Op {
+		case vm.MSTORE:
+			words[newLinearForm(state.Peek(0))] = state.Peek(1)
+		case vm.RETURN:
+			returned = true
+			res = returnTypes(state.Peek(0), state.Peek(1), words)
+		case vm.STOP:
+			stopped = true
+		}
+		return true
+	})
+	if res == nil && !returned && stopped {
+		return []string{}
+	}
+	return res
+}
+
+// returnTypes reads the types of the RETURN data, nil when the size cannot be determined
+func returnTypes(offset StackValue, size StackValue, words map[linearForm]StackValue) []string {
+	start := newLinearForm(offset)
+	head, ok := constantValue(words[start])
+	dynamic := ok && head.Cmp(big32) == 0
+	length, ok := constantValue(size)
+	switch {
+	case !ok:
+		if dynamic {
+			return []string{dynamicOutputType}
+		}
+		return nil
+	case dynamic && length.Cmp(big.NewInt(64)) >= 0:
+		return []string{dynamicOutputType}
+	case !length.IsUint64() || length.Uint64()%32 != 0 || length.Uint64()/32 > outputsMaxWords:
+		return nil
+	}
+	res := make([]string, 0, length.Uint64()/32)
+	for i := uint64(0); i < length.Uint64()/32; i++ {
+		word, ok := words[start.add(new(big.Int).SetUint64(32*i))]
+		if !ok {
+			res = append(res, defaultArgumentType)
+			continue
+		}
+		res = append(res, valueType(word))
+	}
+	return res
+}
+
+// valueType guesses the type of a value from the way it is cleaned up before it is written to memory
+func valueType(v StackValue) string {
+	switch v.Op {
+	case vm.ISZERO, vm.LT, vm.GT, vm.SLT, vm.SGT, vm.EQ:
+		return "bool"
+	case vm.AND:
+		for i, arg := range v.Args {
+			if typ, ok := maskType(arg); ok && !v.Args[1-i].IsKnown() {
+				return typ
+			}
+		}
+	case vm.SIGNEXTEND:
+		if len(v.Args) == 2 && v.Args[0].IsKnown() && v.Args[0].Value.Cmp(big.NewInt(31)) < 0 {
+			return fmt.Sprintf("int%d", 8*(v.Args[0].Value.Uint64()+1))
+		}
+	}
+	return defaultArgumentType
+}
+
+// linearForm is a value written as base + offset, base identifying an unknown value by the instruction which produced
+// it and being empty for known values. MLOAD of a constant address, for e.g. the free memory pointer, is identified by
+// the address so that reloading it gives the same base
+type linearForm struct {
+	base   string
+	offset string
+}
+
+func newLinearForm(v StackValue) linearForm {
+	base, offset := linear(v)
+	return linearForm{base: base, offset: offset.String()}
+}
+
+func (l linearForm) add(c *big.Int) linearForm {
+	offset, _ := new(big.Int).SetString(l.offset, 10)
+	return linearForm{base: l.base, offset: offset.Add(offset, c).String()}
+}
+
+func linear(v StackValue) (string, *big.Int) {
+	if v.IsKnown() {
+		return "", new(big.Int).Set(v.Value)
+	}
+	switch {
+	case v.Op == vm.ADD && len(v.Args) == 2:
+		for i, arg := range v.Args {
+			if arg.IsKnown() {
+				base, offset := linear(v.Args[1-i])
+				return base, offset.Add(offset, arg.Value)
+			}
+		}
+	case v.Op == vm.SUB && len(v.Args) == 2 && v.Args[1].IsKnown():
+		base, offset := linear(v.Args[0])
+		return base, offset.Sub(offset, v.Args[1].Value)
+	case v.Op == vm.MLOAD && len(v.Args) == 1 && v.Args[0].IsKnown():
+		return "mload:" + v.Args[0].Value.Text(16), new(big.Int)
+	}
+	return fmt.Sprintf("%d:%v", v.PC, v.Op), new(big.Int)
+}
+
+// constantValue returns the value when it is known or the difference of two values with the same base
+func constantValue(v StackValue) (*big.Int, bool) {
+	if v.IsKnown() {
+		return v.Value, true
+	}
+	if v.Op != vm.SUB || len(v.Args) != 2 {
+		return nil, false
+	}
+	// SUB pops a, b and pushes a - b
+	baseA, offsetA := linear(v.Args[0])
+	baseB, offsetB := linear(v.Args[1])
+	if baseA != baseB {
+		return nil, false
+	}
+	return offsetA.Sub(offsetA, offsetB), true
+}
@@ -0,0 +1,89 @@
+package asm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyseFunctions_Outputs(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want map[string][]string
+	}{
+		{
+			name: "Solidity 0.8 - Simple Token 2",
+			code: simpleTokenBytecode2,
+			want: map[string][]string{
+				"0x06fdde03": {"string"},
+				"0x70a08231": {"uint256"},
+				"0x8da5cb5b": {"address"},
+				"0xa9059cbb": {"bool"},
+				"0xf2fde38b": {},
+			},
+		},
+		{
+			name: "Solidity 0.4 - USDT",
+			code: usdTBytecode,
+			want: map[string][]string{
+				"0x95d89b41": {"string"},
+				"0x5c975abb": {"bool"},
+				"0x26976e3f": {"address"},
+				"0x27e235e3": {"uint256"},
+				// USDT does not return a bool from transfer
+				"0xa9059cbb": {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewTestDisassembler(tt.code)
+			got := AnalyseFunctions(d, NewSolidityParserWithOpts(WithDisassembler(d)).GetFunctionEntries())
+			for sign, want := range tt.want {
+				if !reflect.DeepEqual(got[sign].Outputs, want) {
+					t.Errorf("AnalyseFunctions() %s outputs = %v, want %v", sign, got[sign].Outputs, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInferOutputs(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []string
+	}{
+		{
+			name: "STOP returns nothing",
+			// PUSH1 1 PUSH1 0 SSTORE STOP
+			code: "600160005500",
+			want: []string{},
+		},
+		{
+			name: "Empty RETURN returns nothing",
+			// PUSH1 0 PUSH1 0 RETURN
+			code: "60006000f3",
+			want: []string{},
+		},
+		{
+			name: "RETURN of unknown size",
+			// PUSH1 0 CALLDATALOAD PUSH1 0 RETURN
+			code: "6000356000f3",
+			want: nil,
+		},
+		{
+			name: "Never returns",
+			// PUSH1 0 DUP1 REVERT
+			code: "600080fd",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InferOutputs(NewTestDisassembler(tt.code), 0); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InferOutputs() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
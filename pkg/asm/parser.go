@@ -3,8 +3,14 @@ package asm
 type BytecodeParser interface {
 	// GetFunctionSigns returns a list of signatures prefixed with 0x
 	GetFunctionSigns() FunctionSigns
+	// GetFunctionEntries returns the map of function signature to the PC where the function body starts
+	GetFunctionEntries() map[string]uint64
+	// GetFunctions returns the map of function signature to the analysis of its body
+	GetFunctions() map[string]Function
 	// GetEventSigns returns a list of event signatures prefixed with 0x
 	GetEventSigns() EventSigns
+	// GetErrorSigns returns a list of custom error signatures prefixed with 0x
+	GetErrorSigns() ErrorSigns
 }
 
 type FunctionSigns struct {
@@ -30,8 +36,41 @@ func (f FunctionSigns) List() []string {
 	return res
 }
 
+// Function is the result of the analysis of the body of a single function
+type Function struct {
+	// Sign is the function signature prefixed with 0x
+	Sign string
+	// Entry is the PC where the function body starts
+	Entry uint64
+	// Arguments are the inferred argument types, see InferArguments
+	Arguments []string
+	// Outputs are the inferred return types, nil when they are unknown, see InferOutputs
+	Outputs []string
+	// StateMutability is the inferred state mutability, see InferStateMutability
+	StateMutability StateMutability
+}
+
+// AnalyseFunctions analyses the body of every function entry
+func AnalyseFunctions(d Disassembler, entries map[string]uint64) map[string]Function {
+	res := make(map[string]Function, len(entries))
+	// Solidity checks CALLVALUE once before the dispatcher when no function is payable
+	guarded := d.hasCallValueGuard(0)
+	for sign, pc := range entries {
+		res[sign] = Function{
+			Sign:            sign,
+			Entry:           pc,
+			Arguments:       InferArguments(d, pc),
+			Outputs:         InferOutputs(d, pc),
+			StateMutability: InferStateMutability(d, pc, guarded),
+		}
+	}
+	return res
+}
+
 type EventSigns struct {
 	Signatures map[string]bool
+	// IndexedTopics is the number of indexed topics (excluding topic0) per event signature when it is known
+	IndexedTopics map[string]int
 }
 
 func NewEventSigns(signs []string) EventSigns {
@@ -39,7 +78,18 @@ func NewEventSigns(signs []string) EventSigns {
 	for _, s := range signs {
 		signMap[s] = true
 	}
-	return EventSigns{Signatures: signMap}
+	return EventSigns{Signatures: signMap, IndexedTopics: make(map[string]int)}
+}
+
+func NewEventSignsFromLogs(logs []EventLog) EventSigns {
+	res := NewEventSigns(nil)
+	for _, log := range logs {
+		res.Signatures[log.Topic0] = true
+		if indexed, ok := res.IndexedTopics[log.Topic0]; !ok || log.IndexedTopics > indexed {
+			res.IndexedTopics[log.Topic0] = log.IndexedTopics
+		}
+	}
+	return res
 }
 
 func (e EventSigns) List() []string {
@@ -52,3 +102,26 @@ func (e EventSigns) List() []string {
 	}
 	return res
 }
+
+type ErrorSigns struct {
+	Signatures map[string]bool
+}
+
+func NewErrorSigns(signs []string) ErrorSigns {
+	signMap := make(map[string]bool)
+	for _, s := range signs {
+		signMap[s] = true
+	}
+	return ErrorSigns{Signatures: signMap}
+}
+
+func (e ErrorSigns) List() []string {
+	res := make([]string, 0)
+	if len(e.Signatures) == 0 {
+		return res
+	}
+	for k := range e.Signatures {
+		res = append(res, k)
+	}
+	return res
+}
@@ -0,0 +1,188 @@
+package asm
+
+import (
+	"bytes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+)
+
+type ProxyKind string
+
+const (
+	// MinimalProxy is the EIP-1167 clone with the implementation address in the code
+	MinimalProxy ProxyKind = "eip-1167"
+	// EIP1967Proxy reads the implementation from the EIP-1967 slot
+	EIP1967Proxy ProxyKind = "eip-1967"
+	// UUPSProxy reads the implementation from the EIP-1822 PROXIABLE slot
+	UUPSProxy ProxyKind = "eip-1822"
+	// TransparentProxy is the OpenZeppelin transparent proxy, with an admin slot next to the implementation slot or since
+	// OpenZeppelin 5 an immutable admin
+	TransparentProxy ProxyKind = "oz-transparent"
+	// GnosisSafeProxy reads the master copy from slot 0
+	GnosisSafeProxy ProxyKind = "gnosis-safe"
+	// DiamondProxy is an EIP-2535 diamond, the facet is looked up by the selector in a mapping
+	DiamondProxy ProxyKind = "eip-2535"
+	// StorageProxy delegates to an address read from a slot which is not standardised
+	StorageProxy ProxyKind = "storage"
+)
+
+var (
+	// eip1967ImplementationSlot is keccak256("eip1967.proxy.implementation") - 1
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// eip1967AdminSlot is keccak256("eip1967.proxy.admin") - 1
+	eip1967AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+	// eip1822ProxiableSlot is keccak256("PROXIABLE")
+	eip1822ProxiableSlot = common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+	// zeppelinOSImplementationSlot is keccak256("org.zeppelinos.proxy.implementation") of the OpenZeppelin proxies
+	// before EIP-1967
+	zeppelinOSImplementationSlot = common.HexToHash("0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3")
+	// gnosisSafeMasterCopySelector is masterCopy(), answered by the proxy itself
+	gnosisSafeMasterCopySelector = []byte{0xa6, 0x19, 0x48, 0x6e}
+	// proxyDeniedAdminAccessSelector is ProxyDeniedAdminAccess(), the error of the OpenZeppelin 5 transparent proxy
+	// which keeps the admin in an immutable and never reads the admin slot
+	proxyDeniedAdminAccessSelector = []byte{0xd2, 0xb5, 0x76, 0xec}
+
+	minimalProxyPrefix = []byte{0x36, 0x3d, 0x3d, 0x37, 0x3d, 0x3d, 0x3d, 0x36, 0x3d}
+	minimalProxySuffix = []byte{0x5a, 0xf4, 0x3d, 0x82, 0x80, 0x3e, 0x90, 0x3d, 0x91}
+	addressMask        = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+)
+
+// Proxy is a contract delegating its calls to an implementation contract
+type Proxy struct {
+	Kind ProxyKind
+	// Implementation is the address of the implementation when it is part of the code, for e.g. EIP-1167
+	Implementation string
+	// Slot is the storage slot holding the implementation address, prefixed with 0x
+	Slot string
+}
+
+// HasSlot reports whether the implementation has to be read from the storage of the proxy
+func (p Proxy) HasSlot() bool {
+	return p.Implementation == "" && p.Slot != ""
+}
+
+// DetectProxy recognises EIP-1167 minimal proxies from their code and the proxies reading the implementation from
+// storage by emulating up to the DELEGATECALL, the target is expected to be SLOAD(<slot>). A slot computed with
+// KECCAK256 is a mapping lookup, which is how diamonds find the facet of a selector
+//
+//	Ref: https://eips.ethereum.org/EIPS/eip-1167
+//	Ref: https://eips.ethereum.org/EIPS/eip-1967
+//	Ref: https://eips.ethereum.org/EIPS/eip-1822
+//	Ref: https://eips.ethereum.org/EIPS/eip-2535
+func DetectProxy(d Disassembler) (Proxy, bool) {
+	if implementation, ok := minimalProxyImplementation(d.OriginalByteCode); ok {
+		return Proxy{Kind: MinimalProxy, Implementation: implementation}, true
+	}
+	slot, ok := delegateCallSlot(d)
+	switch {
+	case !ok:
+		return Proxy{}, false
+	case slot.IsKnown():
+		hash := common.BigToHash(slot.Value)
+		return Proxy{Kind: storageProxyKind(d, hash), Slot: hexutil.Encode(hash.Bytes())}, true
+	case slot.Op == vm.KECCAK256:
+		return Proxy{Kind: DiamondProxy}, true
+	}
+	return Proxy{}, false
+}
+
+// minimalProxyImplementation matches the EIP-1167 runtime code, including the variants pushing a shorter address
+//
+//	CALLDATASIZE RETURNDATASIZE RETURNDATASIZE CALLDATACOPY RETURNDATASIZE RETURNDATASIZE RETURNDATASIZE
+//	CALLDATASIZE RETURNDATASIZE PUSH<n> <address> GAS DELEGATECALL ...
+func minimalProxyImplementation(code []byte) (string, bool) {
+	if !bytes.HasPrefix(code, minimalProxyPrefix) || len(code) <= len(minimalProxyPrefix) {
+		return "", false
+	}
+	op := vm.OpCode(code[len(minimalProxyPrefix)])
+	if op < vm.PUSH1 || op > vm.PUSH20 {
+		return "", false
+	}
+	size := int(op-vm.PUSH1) + 1
+	start := len(minimalProxyPrefix) + 1
+	if len(code) < start+size || !bytes.HasPrefix(code[start+size:], minimalProxySuffix) {
+		return "", false
+	}
+	return common.BytesToAddress(code[start : start+size]).Hex(), true
+}
+
+// delegateCallSlot returns the slot of the first DELEGATECALL target read from storage. Function bodies are not
+// followed, a function delegating to a stored address does not make the contract a proxy
+func delegateCallSlot(d Disassembler) (StackValue, bool) {
+	bodies := make(map[uint64]bool)
+	for _, pc := range FindDispatchEntries(d) {
+		bodies[pc] = true
+	}
+	var slot *StackValue
+	NewEmulator(d).Run(func(inst Instruction, state *ExecutionState) bool {
+		if slot != nil || bodies[inst.PC] {
+			return false
+		}
+		if inst.Op != vm.DELEGATECALL {
+			return true
+		}
+		// DELEGATECALL pops gas, address, ...
+		if s, ok := loadedSlot(state.Peek(1)); ok {
+			slot = &s
+		}
+		return true
+	})
+	if slot == nil {
+		return StackValue{}, false
+	}
+	return *slot, true
+}
+
+// loadedSlot matches SLOAD(<slot>) optionally converted to an address with AND(<2^160-1>, ...) or SHR(96, ...)
+// when the address is packed in the high bytes
+func loadedSlot(v StackValue) (StackValue, bool) {
+	if v.IsKnown() || len(v.Args) == 0 {
+		return StackValue{}, false
+	}
+	switch v.Op {
+	case vm.SLOAD:
+		return v.Args[0], true
+	case vm.SHR:
+		if v.Args[0].IsConst(96) {
+			return loadedSlot(v.Args[1])
+		}
+	case vm.AND:
+		for i, arg := range v.Args {
+			if arg.IsKnown() && arg.Value.Cmp(addressMask) == 0 {
+				return loadedSlot(v.Args[1-i])
+			}
+		}
+	}
+	return StackValue{}, false
+}
+
+func storageProxyKind(d Disassembler, slot common.Hash) ProxyKind {
+	switch slot {
+	case eip1967ImplementationSlot:
+		if d.pushes(eip1967AdminSlot.Bytes()) || d.pushes(proxyDeniedAdminAccessSelector) {
+			return TransparentProxy
+		}
+		return EIP1967Proxy
+	case eip1822ProxiableSlot:
+		return UUPSProxy
+	case zeppelinOSImplementationSlot:
+		return TransparentProxy
+	case common.Hash{}:
+		if d.pushes(gnosisSafeMasterCopySelector) {
+			return GnosisSafeProxy
+		}
+	}
+	return StorageProxy
+}
+
+// pushes reports whether any PUSH instruction starts with the value
+func (d Disassembler) pushes(value []byte) bool {
+	for _, inst := range d.Instructions {
+		if inst.Op.IsPush() && bytes.HasPrefix(inst.Arg, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package asm
+
+import (
+	"testing"
+)
+
+const (
+	minimalProxyBytecode = "363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3"
+	// gnosisSafeProxyBytecode follows the runtime code of GnosisSafeProxy 1.3.0
+	gnosisSafeProxyBytecode = "608060405273ffffffffffffffffffffffffffffffffffffffff600054167fa619486e000000000000000000000000000000000000000000" +
+		"0000000000000060003514156050578060005260206000f35b3660008037600080366000845af43d6000803e60008114156070573d6000fd" +
+		"5b3d6000f3fea2646970667358221220d1429297349653a4918076d650332de1a1068c5f3e07c5c82360c277770b955264736f6c63430007" +
+		"060033"
+	// eip1967ProxyBytecode is a hand assembled proxy delegating to AND(<2^160-1>, SLOAD(<slot>))
+	eip1967ProxyBytecode = "7f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc5473ffffffffffffffffffffffffffffffffffffff" +
+		"ff1636600060003760006000366000845af400"
+	// ozTransparentProxyV4Bytecode is hand assembled after the solc 0.8 optimised runtime of the OpenZeppelin 4.8
+	// TransparentUpgradeableProxy: a dispatcher of the ifAdmin functions and a fallback which reverts when the caller is
+	// the admin read from the admin slot before the DELEGATECALL
+	ozTransparentProxyV4Bytecode = "60806040526004361061005e5760003560e01c80635c60da1b116100435780635c60da1b14610" +
+		"0a85780638f283970146100bd578063f851a440146100dd5761006b565b80633659cfe6146100755780634f1ef286146100955761006b565" +
+		"b3661006b5761007361034c565b61007361034c565b005b34801561008157600080fd5b5061007361009036600461010e565b61013e565b6" +
+		"100736100a336600461010e565b610162565b3480156100b457600080fd5b506100f2610273565b3480156100c957600080fd5b506100736" +
+		"100d836600461010e565b6101b4565b3480156100e957600080fd5b506100f261024f565b6040516001600160a01b0390911681526020016" +
+		"0405180910390f35b60006020828403121561012057600080fd5b81356001600160a01b038116811461013757600080fd5b9392505050565" +
+		"b336101476102f0565b6001600160a01b03160361015a57610292565b61028f61034c565b3361016b6102f0565b6001600160a01b0316036" +
+		"1015a578061018390610292565b60243560040180359060200160405191808284376000808285875af4156101aa5750505050565b3d60008" +
+		"03e3d6000fd5b336101bd6102f0565b6001600160a01b03160361015a57806001600160a01b03166101de57600080fd5b6101e66102f0565" +
+		"b604080516001600160a01b03928316815283831660208201527f7e644d79422f17c01e4894b5f4f588d331ebfa28653d42ae832dc59e38c" +
+		"9798f910160405180910390a1507fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d610355565b336102586102f" +
+		"0565b6001600160a01b03160361026b576102f0565b61007361034c565b3361027c6102f0565b6001600160a01b03160361026b5761031e5" +
+		"65b50565b803b61029d57600080fd5b807f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc556001600160a" +
+		"01b03167fbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b600080a2565b7fb53127684a568b3173ae13b9f" +
+		"8a6016e243e63b6e8ee1178d6a717850b5d6103546001600160a01b031690565b7f360894a13ba1a3210667c828492db98dca3e2076cc373" +
+		"5a920a3ca505d382bbc546001600160a01b031690565b610354610361565b61035c61031e565b6103f7565b6103696102f0565b600160016" +
+		"0a01b031633036103f55760405162461bcd60e51b815260206004820152604260248201527f5472616e73706172656e74557067726164656" +
+		"1626c6550726f78793a2061646d60448201527f696e2063616e6e6f742066616c6c6261636b20746f2070726f78792074617267606482015" +
+		"261657460f01b608482015260a40160405180910390fd5b565b3660008037600080366000845af43d6000803e808015610416573d6000f35" +
+		"b3d6000fd"
+	// ozTransparentProxyV5Bytecode is hand assembled after the runtime of the OpenZeppelin 5 TransparentUpgradeableProxy,
+	// the admin is an immutable compared with the caller and only upgradeToAndCall is dispatched for it
+	ozTransparentProxyV5Bytecode = "608060405261000c61000e565b005b7f000000000000000000000000000000000000000000000" +
+		"00000000000000000ad6001600160a01b0316330361007957634f1ef28660e01b6000356001600160e01b031916146100715760405163d2b" +
+		"576ec60e01b815260040160405180910390fd5b61000c610089565b61000c610084610193565b6101c1565b600435806001600160a01b031" +
+		"681146100a157600080fd5b6024356004018035906020016040519180828437836100bf90610107565b80156100e2576000808285875af41" +
+		"56100d85750505050565b3d6000803e3d6000fd5b34156101015760405163b398979f60e01b815260040160405180910390fd5b505050505" +
+		"65b806001600160a01b03163b61014057604051634c9c8ce360e01b81526001600160a01b039190911660048201526024016040518091039" +
+		"0fd5b807f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc556001600160a01b03167fbc7cd75a20ee27fd9" +
+		"adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b600080a2565b7f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a" +
+		"3ca505d382bbc546001600160a01b031690565b3660008037600080366000845af43d6000803e8080156101e0573d6000f35b3d6000fd"
+	// ozERC1967ProxyBytecode is hand assembled after the runtime of the OpenZeppelin 4.8 ERC1967Proxy, the proxy of UUPS
+	// implementations, the fallback calls _delegate(_implementation()) as internal functions
+	ozERC1967ProxyBytecode = "6080604052366100115761001961001b565b61001961001b565b005b610023610028565b610060565b6" +
+		"00061005b7f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc546001600160a01b031690565b905090565b3" +
+		"660008037600080366000845af43d6000803e80801561007f573d6000f35b3d6000fd"
+	// eip1822ProxyBytecode is hand assembled after the reference Proxy of EIP-1822 which delegates sub(gas(), 10000)
+	eip1822ProxyBytecode = "60806040527fc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7543660008" +
+		"037600080366000846127105a03f43d806000803e81801561004a57816000f35b816000fd"
+	// diamond3Bytecode is hand assembled after the fallback of the diamond-3 reference implementation of EIP-2535, the
+	// facet is ds.selectorToFacetAndPosition[msg.sig].facetAddress and is required to be set
+	diamond3Bytecode = "60806040523661000b57005b600080356001600160e01b03191681527fc8fcad8db84d3cc18b4c41d551ea0ee" +
+		"66dd599cde068d998e57d5e09332c131c60205260409020546001600160a01b03168061009e5760405162461bcd60e51b815260206004820" +
+		"181905260248201527f4469616d6f6e643a2046756e6374696f6e20646f6573206e6f7420657869737460448201526064016040518091039" +
+		"0fd5b3660008037600080366000845af43d6000803e8080156100bd573d6000f35b3d6000fd"
+	// diamond2Bytecode is hand assembled after the fallback of the diamond-2 reference implementation of EIP-2535, the
+	// facet is the high 20 bytes of ds.facets[msg.sig]
+	diamond2Bytecode = "60806040523661000b57005b600080356001600160e01b03191681527fc8fcad8db84d3cc18b4c41d551ea0ee" +
+		"66dd599cde068d998e57d5e09332c131c602052604090205460601c806100985760405162461bcd60e51b815260206004820181905260248" +
+		"201527f4469616d6f6e643a2046756e6374696f6e20646f6573206e6f74206578697374604482015260640160405180910390fd5b3660008" +
+		"037600080366000845af43d6000803e8080156100b7573d6000f35b3d6000fd"
+)
+
+func TestDetectProxy(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		wantProxy Proxy
+		wantFound bool
+	}{
+		{
+			name:      "EIP-1167 minimal proxy",
+			code:      minimalProxyBytecode,
+			wantProxy: Proxy{Kind: MinimalProxy, Implementation: "0xBEbeBeBEbeBebeBeBEBEbebEBeBeBebeBeBebebe"},
+			wantFound: true,
+		},
+		{
+			name:      "EIP-1967 proxy",
+			code:      eip1967ProxyBytecode,
+			wantProxy: Proxy{Kind: EIP1967Proxy, Slot: "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"},
+			wantFound: true,
+		},
+		{
+			name: "OpenZeppelin transparent proxy",
+			code: "7fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d61035450" + eip1967ProxyBytecode,
+			wantProxy: Proxy{Kind: TransparentProxy,
+				Slot: "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"},
+			wantFound: true,
+		},
+		{
+			name: "EIP-1822 proxy",
+			code: "7fc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf75473ffffffffffffffffffffffffffffffffffffffff" +
+				"1636600060003760006000366000845af400",
+			wantProxy: Proxy{Kind: UUPSProxy, Slot: "0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7"},
+			wantFound: true,
+		},
+		{
+			name:      "Gnosis Safe proxy",
+			code:      gnosisSafeProxyBytecode,
+			wantProxy: Proxy{Kind: GnosisSafeProxy, Slot: "0x0000000000000000000000000000000000000000000000000000000000000000"},
+			wantFound: true,
+		},
+		{
+			name:      "Non standard slot",
+			code:      "60055473ffffffffffffffffffffffffffffffffffffffff1636600060003760006000366000845af400",
+			wantProxy: Proxy{Kind: StorageProxy, Slot: "0x0000000000000000000000000000000000000000000000000000000000000005"},
+			wantFound: true,
+		},
+		{
+			name: "EIP-2535 diamond",
+			code: "600035600052602060002054" + "73ffffffffffffffffffffffffffffffffffffffff16" +
+				"36600060003760006000366000845af400",
+			wantProxy: Proxy{Kind: DiamondProxy},
+			wantFound: true,
+		},
+		{
+			name:      "EIP-2535 diamond with the facet address packed in the high bytes",
+			code:      "600035600052602060002054" + "60601c" + "36600060003760006000366000845af400",
+			wantProxy: Proxy{Kind: DiamondProxy},
+			wantFound: true,
+		},
+		{
+			name: "OpenZeppelin 4 transparent proxy with an admin check before the DELEGATECALL",
+			code: ozTransparentProxyV4Bytecode,
+			wantProxy: Proxy{Kind: TransparentProxy,
+				Slot: "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"},
+			wantFound: true,
+		},
+		{
+			name: "OpenZeppelin 5 transparent proxy with an immutable admin",
+			code: ozTransparentProxyV5Bytecode,
+			wantProxy: Proxy{Kind: TransparentProxy,
+				Slot: "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"},
+			wantFound: true,
+		},
+		{
+			name:      "OpenZeppelin ERC1967Proxy of a UUPS implementation",
+			code:      ozERC1967ProxyBytecode,
+			wantProxy: Proxy{Kind: EIP1967Proxy, Slot: "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"},
+			wantFound: true,
+		},
+		{
+			name:      "EIP-1822 reference proxy",
+			code:      eip1822ProxyBytecode,
+			wantProxy: Proxy{Kind: UUPSProxy, Slot: "0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7"},
+			wantFound: true,
+		},
+		{
+			name:      "EIP-2535 diamond-3 requiring the facet to be set",
+			code:      diamond3Bytecode,
+			wantProxy: Proxy{Kind: DiamondProxy},
+			wantFound: true,
+		},
+		{
+			name:      "EIP-2535 diamond-2 with the facet packed with the selector",
+			code:      diamond2Bytecode,
+			wantProxy: Proxy{Kind: DiamondProxy},
+			wantFound: true,
+		},
+		{
+			name:      "Not a proxy - Simple Token 2",
+			code:      simpleTokenBytecode2,
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := DetectProxy(NewTestDisassembler(tt.code))
+			if found != tt.wantFound {
+				t.Fatalf("DetectProxy() found = %v, want %v", found, tt.wantFound)
+			}
+			if got != tt.wantProxy {
+				t.Errorf("DetectProxy() got = %+v, want %+v", got, tt.wantProxy)
+			}
+		})
+	}
+}
@@ -27,8 +27,9 @@ func NewSolidityParserWithOpts(opts ...SolidityParserOpt) SolidityParser {
 	return p
 }
 
+// NewSolidityParser accepts runtime or creation code, only the runtime section of creation code is parsed
 func NewSolidityParser(evmCode []byte) (SolidityParser, error) {
-	d, err := NewDisassembler(evmCode)
+	d, err := NewDisassembler(RuntimeCode(evmCode))
 	if err != nil {
 		return SolidityParser{}, err
 	}
@@ -44,18 +45,39 @@ func NewSolidityParserStr(code string) (SolidityParser, error) {
 	return NewSolidityParser(script)
 }
 
-// GetFunctionSigns For Solidity we are looking for the following pattern in the Bytecode
+// GetFunctionSigns For Solidity we walk the selector dispatch tree, see FindDispatchEntries, and additionally look
+// for the following pattern in the Bytecode
 //
 //	DUP1 PUSH4 <BYTE4> EQ PUSH1 <BYTE1> JUMPI
 //	Ref: https://github.com/ethereum/solidity/blob/242096695fd3e08cc3ca3f0a7d2e06d09b5277bf/libsolidity/codegen/ContractCompiler.cpp#L333
 func (p SolidityParser) GetFunctionSigns() FunctionSigns {
 	functionSelectors := make([]string, 0)
+	for sign := range p.GetFunctionEntries() {
+		functionSelectors = append(functionSelectors, sign)
+	}
+	return NewFunctionSigns(functionSelectors)
+}
+
+// GetFunctionEntries returns the map of function signature to the PC where the function body starts
+func (p SolidityParser) GetFunctionEntries() map[string]uint64 {
+	entries := FindDispatchEntries(p.Disassembler)
+	for sign, pc := range p.linearDispatchEntries() {
+		if _, ok := entries[sign]; !ok {
+			entries[sign] = pc
+		}
+	}
+	return entries
+}
+
+// linearDispatchEntries matches the DUP1 PUSH4 <BYTE4> EQ PUSH <DEST> JUMPI sequence
+func (p SolidityParser) linearDispatchEntries() map[string]uint64 {
+	entries := make(map[string]uint64)
 	if len(p.Instructions) == 0 {
-		return NewFunctionSigns(functionSelectors)
+		return entries
 	}
 	for i := 0; i < len(p.Instructions); i++ {
 		inst := p.Instructions[i]
-		if inst.Op == vm.JUMPI {
+		if inst.Op == vm.JUMPI && i >= 4 {
 			var (
 				// Jump destination
 				dest *big.Int
@@ -104,44 +126,35 @@ func (p SolidityParser) GetFunctionSigns() FunctionSigns {
 				continue
 			}
 
-			functionSelectors = append(functionSelectors, sign)
+			entries[sign] = instAtJumpDest.PC
 		}
 	}
-	return NewFunctionSigns(functionSelectors)
+	return entries
 }
 
-// GetEventSigns For Solidity we are looking for the following pattern in the Bytecode
-//
-//	PUSH32 <BYTE32>
-//
-//	Note: This is not the correct way to extract event hashes. Correct by building the stack step by step and
-//	analyse it when a LOG instruction is encountered (TODO)
+// GetFunctions analyses the body of every function, see AnalyseFunctions
+func (p SolidityParser) GetFunctions() map[string]Function {
+	return AnalyseFunctions(p.Disassembler, p.GetFunctionEntries())
+}
+
+// GetEventSigns For Solidity we emulate the stack along every statically resolvable path and read topic0 at each
+// LOG1-LOG4 instruction. Only constant topics are reported, anonymous events are not detectable
 func (p SolidityParser) GetEventSigns() EventSigns {
-	eventSignatures := make([]string, 0)
 	if len(p.Instructions) == 0 {
-		return NewEventSigns(eventSignatures)
+		return NewEventSigns(nil)
 	}
-	var (
-		push32Found bool
-		push32Index int
-		interSign   string
-	)
-	for i := 0; i < len(p.Instructions); i++ {
-		inst := p.Instructions[i]
-		if inst.Op == vm.PUSH32 && len(inst.Arg) > 0 {
-			interSign = hexutil.Encode(inst.Arg)
-			push32Found = true
-			push32Index = i
-		}
-		if push32Found && (inst.Op == vm.LOG0 || inst.Op == vm.LOG1 ||
-			inst.Op == vm.LOG2 || inst.Op == vm.LOG3 || inst.Op == vm.LOG4) {
-			if i-push32Index > 25 {
-				push32Found = false
-				continue
-			}
-			eventSignatures = append(eventSignatures, interSign)
-			push32Found = false
-		}
+	return NewEventSignsFromLogs(NewEmulator(p.Disassembler).EventLogs())
+}
+
+// GetErrorSigns For Solidity we emulate the stack and the memory from the start of the code and from every function
+// entry, and read the selector written at the start of the revert data, see RevertSelectorsFrom
+func (p SolidityParser) GetErrorSigns() ErrorSigns {
+	if len(p.Instructions) == 0 {
+		return NewErrorSigns(nil)
+	}
+	pcs := []uint64{0}
+	for _, pc := range p.GetFunctionEntries() {
+		pcs = append(pcs, pc)
 	}
-	return NewEventSigns(eventSignatures)
+	return NewErrorSigns(NewEmulator(p.Disassembler, WithMemory()).RevertSelectorsFrom(pcs...))
 }
@@ -0,0 +1,174 @@
+package asm
+
+import (
+	"encoding/hex"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"math/big"
+)
+
+const (
+	// vyperMaxSteps bounds each emulation of the dispatcher, function bodies are not followed
+	vyperMaxSteps = 50_000
+	// vyperMaxRuns bounds the number of bucket assignments tried for the selector tables
+	vyperMaxRuns = 1024
+	// vyperMaxBuckets is the largest modulus treated as a selector table bucket count
+	vyperMaxBuckets = 1 << 12
+)
+
+type VyperParser struct {
+	Disassembler
+}
+
+// NewVyperParser accepts runtime or creation code, only the runtime section of creation code is parsed
+func NewVyperParser(evmCode []byte) (VyperParser, error) {
+	d, err := NewDisassembler(RuntimeCode(evmCode))
+	if err != nil {
+		return VyperParser{}, err
+	}
+	return VyperParser{Disassembler: d}, nil
+}
+
+// NewVyperParserStr accepts bytecode string without leading 0x
+func NewVyperParserStr(code string) (VyperParser, error) {
+	script, err := hex.DecodeString(code)
+	if err != nil {
+		return VyperParser{}, err
+	}
+	return NewVyperParser(script)
+}
+
+func (p VyperParser) GetFunctionSigns() FunctionSigns {
+	functionSelectors := make([]string, 0)
+	for sign := range p.GetFunctionEntries() {
+		functionSelectors = append(functionSelectors, sign)
+	}
+	return NewFunctionSigns(functionSelectors)
+}
+
+// GetFunctionEntries returns the map of function signature to the PC where the function body starts. Vyper
+// dispatches in one of the following ways depending on the compiler version
+//
+//	< 0.3.0   MSTORE(0x1c, CALLDATALOAD(0)) then PUSH4 <BYTE4> PUSH1 0 MLOAD EQ ISZERO PUSH2 <next> JUMPI
+//	0.3.x     PUSH4 <BYTE4> DUP2 XOR PUSH2 <next> JUMPI on SHR(224, CALLDATALOAD(0))
+//	>= 0.3.10 jump tables indexed by MOD(selector, <buckets>), the selectors are either pushed in the bucket
+//	          (sparse) or read with CODECOPY from a data section next to the jump label (dense)
+//
+// The selector tables are resolved by emulating the dispatcher once for every bucket
+//
+//	Ref: https://github.com/vyperlang/vyper/blob/v0.3.10/vyper/codegen/module.py
+func (p VyperParser) GetFunctionEntries() map[string]uint64 {
+	entries := make(map[string]uint64)
+	if len(p.Instructions) == 0 {
+		return entries
+	}
+	e := NewEmulator(p.Disassembler, WithMemory(), WithMaxSteps(vyperMaxSteps))
+	// Every assignment fixes the result of the MOD instructions at the given PCs
+	pending := []map[uint64]int64{{}}
+	for runs := 0; len(pending) > 0 && runs < vyperMaxRuns; runs++ {
+		assignment := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		modPC, buckets, found := p.runDispatcher(e, assignment, entries)
+		if !found {
+			continue
+		}
+		for bucket := buckets - 1; bucket >= 0; bucket-- {
+			next := make(map[uint64]int64, len(assignment)+1)
+			for pc, v := range assignment {
+				next[pc] = v
+			}
+			next[modPC] = bucket
+			pending = append(pending, next)
+		}
+	}
+	return entries
+}
+
+// runDispatcher emulates the dispatcher with the results of the selector MODs fixed by assignment and adds the
+// function entries found. It returns the PC and the modulus of the first selector MOD without an assignment
+func (p VyperParser) runDispatcher(e Emulator, assignment map[uint64]int64,
+	entries map[string]uint64) (modPC uint64, buckets int64, found bool) {
+	bodies := make(map[uint64]bool)
+	for _, pc := range entries {
+		bodies[pc] = true
+	}
+	// matched is the selector loaded from a data section, the next computed jump is to its body
+	matched := ""
+	e.Run(func(inst Instruction, state *ExecutionState) bool {
+		if bodies[inst.PC] {
+			return false
+		}
+		if top := state.Peek(0); !top.IsKnown() && top.Op == vm.MOD {
+			if bucket, ok := assignment[top.PC]; ok {
+				state.Stack[len(state.Stack)-1] = StackValue{Value: big.NewInt(bucket), PC: top.PC, Op: vm.MOD, FromCalldata: true}
+			}
+		}
+		switch inst.Op {
+		case vm.MOD:
+			if _, ok := assignment[inst.PC]; ok {
+				return true
+			}
+			selector, modulus := state.Peek(0), state.Peek(1)
+			if !selector.IsKnown() && selector.FromCalldata && modulus.IsKnown() && modulus.Value.Sign() > 0 &&
+				modulus.Value.Cmp(big.NewInt(vyperMaxBuckets)) <= 0 {
+				if !found {
+					modPC, buckets, found = inst.PC, modulus.Value.Int64(), true
+				}
+				return false
+			}
+		case vm.JUMPI:
+			check, ok := matchSelectorCheck(state.Peek(1), isVyperSelector)
+			if !ok {
+				return true
+			}
+			if check.IsInline() {
+				e.recordInlineEntry(inst, state, check, entries, bodies)
+			} else {
+				matched = check.Sign
+			}
+		case vm.JUMP:
+			dest := state.Peek(0)
+			if matched == "" || !dest.IsKnown() || dest.Op.IsPush() || !dest.Value.IsUint64() {
+				return true
+			}
+			if _, ok := e.JumpDestinations[dest.Value.Uint64()]; ok {
+				entries[matched] = dest.Value.Uint64()
+				bodies[dest.Value.Uint64()] = true
+				matched = ""
+				return false
+			}
+		}
+		return true
+	})
+	return modPC, buckets, found
+}
+
+// GetFunctions analyses the body of every function, see AnalyseFunctions
+func (p VyperParser) GetFunctions() map[string]Function {
+	return AnalyseFunctions(p.Disassembler, p.GetFunctionEntries())
+}
+
+func (p VyperParser) GetEventSigns() EventSigns {
+	if len(p.Instructions) == 0 {
+		return NewEventSigns(nil)
+	}
+	pcs := []uint64{0}
+	for _, pc := range p.GetFunctionEntries() {
+		pcs = append(pcs, pc)
+	}
+	return NewEventSignsFromLogs(NewEmulator(p.Disassembler).EventLogsFrom(pcs...))
+}
+
+// GetErrorSigns Vyper has no custom errors, it reverts with Error(string) or without data
+func (p VyperParser) GetErrorSigns() ErrorSigns {
+	return NewErrorSigns(nil)
+}
+
+// isVyperSelector additionally accepts the selector stored by old Vyper versions at memory 0
+//
+//	MSTORE(0x1c, CALLDATALOAD(0)) ... MLOAD(0)
+func isVyperSelector(v StackValue) bool {
+	if IsSelectorValue(v) {
+		return true
+	}
+	return !v.IsKnown() && v.Op == vm.MLOAD && len(v.Args) == 1 && v.Args[0].IsConst(0)
+}
@@ -0,0 +1,277 @@
+package asm
+
+import (
+	"testing"
+)
+
+// Hand assembled dispatchers of the different Vyper versions, transfer(address,uint256) emits Transfer and
+// allowance(address,address) emits Approval. They follow the layouts of vyper 0.2 (MLOAD), 0.3.0 to 0.3.9 (XOR) and
+// 0.3.10 with -O gas (sparse table) and -O codesize (dense table) but are not compiler output, they are to be replaced
+// by the runtimes of a compiled contract of each version
+const (
+	// vyperMloadBytecode stores the selector at memory 0 and compares with PUSH4 PUSH1 0 MLOAD EQ ISZERO
+	vyperMloadBytecode = "600035601c5263a9059cbb600051141561003b577fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" +
+		"60206000a1005b6370a08231600051141561004b57005b600080fd"
+	// vyperLinearBytecode compares the shifted selector with PUSH4 DUP2 XOR
+	vyperLinearBytecode = "60003560e01c63a9059cbb811861003d573461004a577fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" +
+		"60206000a1005b6318160ddd811861004a57005b600080fd"
+	// vyperSparseBytecode jumps to one of 2 buckets through a table of labels indexed by MOD(selector, 2)
+	vyperSparseBytecode = "60003560e01c6002810660011b61009301600290601e39600051565b63dd62ed3e811861008e577f8c5be1e5ebec7d5bd14f7142" +
+		"7d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560206000a1005b63a9059cbb8118610081577fddf252ad1be2c89b69c2b068fc378daa952ba7f16" +
+		"3c4a11628f55a4df523b3ef60206000a1005b6370a08231811861008e57005b600080fd001b004e"
+	// vyperDenseBytecode reads the bucket header and the function info holding the selector and the label from the
+	// data section at the end of the code
+	vyperDenseBytecode = "60003560e01c600281066005026100a701600590601b396000518060ff168160181c830260181c066007029060081c61ffff160160" +
+		"0790601939600051818160181c14156100a25760081c61ffff16565b7f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3" +
+		"b92560206000a1005b7fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206000a1005b005b600080fd000000b1" +
+		"01000100b802dd62ed3e00500070a0823100a000a9059cbb007800"
+)
+
+// Hand assembled runtimes of the ERC20 token of the Vyper examples, with its 12 functions and the Transfer and Approval
+// events, laid out as each Vyper version compiles it. They are not compiler output either but the dispatchers, the
+// clamps, the memory layout and the internal _burn call follow the compiler, and executed in an EVM they behave as the
+// token
+const (
+	// vyperERC20MloadBytecode is laid out as vyper 0.2, the selector is stored at memory 0 after the calldata size
+	// check and the clamp bounds are kept in memory
+	vyperERC20MloadBytecode = "600436101561000d57610555565b600035601c5274010000000000000000000000000000000000000000" +
+		"6020526f7fffffffffffffffffffffffffffffff6040527fffffffffffffffffffffffffffffffff80000000000000000000000000000000" +
+		"60605274012a05f1fffffffffffffffffffffffffdabf41c006080527ffffffffffffffffffffffffed5fa0e000000000000000000000000" +
+		"000000000060a0526306fdde0360005114156100d55734156100ba57600080fd5b60206101a0526000546101c0526001546101e052606061" +
+		"01a0f35b6395d89b4160005114156101095734156100ee57600080fd5b60206101a0526002546101c0526003546101e05260606101a0f35b" +
+		"63313ce567600051141561013057341561012257600080fd5b6004546101a05260206101a0f35b6318160ddd600051141561015757341561" +
+		"014957600080fd5b6007546101a05260206101a0f35b6370a08231600051141561019a57341561017057600080fd5b600435806020511115" +
+		"6105d5576005906100e0526100c05260406100c020546101a05260206101a0f35b63dd62ed3e60005114156101f85734156101b357600080" +
+		"fd5b6004358060205111156105d5576006906100e0526100c05260406100c0206024358060205111156105d5576100e0526100c052604061" +
+		"00c020546101a05260206101a0f35b63a9059cbb60005114156102a057341561021157600080fd5b6004358060205111156105d557336005" +
+		"906100e0526100c05260406100c02080546024358082106105d55790039055806005906100e0526100c05260406100c02080546024358101" +
+		"8082116105d557905090556024356101a052337fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206101" +
+		"a0a360016101a05260206101a0f35b6323b872dd60005114156103935734156102b957600080fd5b6004358060205111156105d557506024" +
+		"358060205111156105d557506004356005906100e0526100c05260406100c02080546044358082106105d557900390556024356005906100" +
+		"e0526100c05260406100c020805460443581018082116105d557905090556004356006906100e0526100c05260406100c020336100e05261" +
+		"00c05260406100c02080546044358082106105d557900390556044356101a0526024356004357fddf252ad1be2c89b69c2b068fc378daa95" +
+		"2ba7f163c4a11628f55a4df523b3ef60206101a0a360016101a05260206101a0f35b63095ea7b3600051141561041b5734156103ac576000" +
+		"80fd5b6004358060205111156105d557336006906100e0526100c05260406100c020816100e0526100c05260406100c02060243590556024" +
+		"356101a052337f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560206101a0a360016101a05260206101a0" +
+		"f35b6340c10f1960005114156104bb57341561043457600080fd5b6004358060205111156105d5576008543314156105d55780156105d557" +
+		"6007805460243581018082116105d55790509055806005906100e0526100c05260406100c020805460243581018082116105d55790509055" +
+		"6024356101a05260007fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206101a0a3005b6342966c6860" +
+		"005114156104e85734156104d457600080fd5b3361016052600435610180526105d361055a565b6379cc6790600051141561055457341561" +
+		"050157600080fd5b6004358060205111156105d5576006906100e0526100c05260406100c020336100e0526100c05260406100c020805460" +
+		"24358082106105d5579003905560043561016052602435610180526105d361055a565b5b600080fd5b61016051156105d557600780546101" +
+		"80518082106105d55790039055610160516005906100e0526100c05260406100c0208054610180518082106105d557900390556101805161" +
+		"01a0526000610160517fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206101a0a3565b005b600080fd"
+
+	// vyperERC20LinearBytecode is laid out as vyper 0.3.0 to 0.3.9 with a linear XOR dispatcher
+	vyperERC20LinearBytecode = "6003361161000c57610448565b60003560e01c6306fdde03811861003d57346104c8576020610040526" +
+		"0005461006052600154610080526060610040f35b6395d89b41811861006857346104c857602061004052600254610060526003546100805" +
+		"26060610040f35b63313ce567811861008657346104c857600454610040526020610040f35b6318160ddd81186100a457346104c85760075" +
+		"4610040526020610040f35b6370a0823181186100dc57346104c8576004358060a01c6104c85760059061002052610000526040610000205" +
+		"4610040526020610040f35b63dd62ed3e811861012d57346104c8576004358060a01c6104c85760069061002052610000526040610000206" +
+		"024358060a01c6104c857610020526100005260406100002054610040526020610040f35b63a9059cbb81186101ca57346104c8576004358" +
+		"060a01c6104c85733600590610020526100005260406100002080546024358082106104c8579003905580600590610020526100005260406" +
+		"1000020805460243581018082116104c8579050905560243561004052337fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f" +
+		"55a4df523b3ef6020610040a36001610040526020610040f35b6323b872dd81186102b057346104c8576004358060a01c6104c8575060243" +
+		"58060a01c6104c85750600435600590610020526100005260406100002080546044358082106104c85790039055602435600590610020526" +
+		"1000052604061000020805460443581018082116104c85790509055600435600690610020526100005260406100002033610020526100005" +
+		"260406100002080546044358082106104c85790039055604435610040526024356004357fddf252ad1be2c89b69c2b068fc378daa952ba7f" +
+		"163c4a11628f55a4df523b3ef6020610040a36001610040526020610040f35b63095ea7b3811861032d57346104c8576004358060a01c610" +
+		"4c857336006906100205261000052604061000020816100205261000052604061000020602435905560243561004052337f8c5be1e5ebec7" +
+		"d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b9256020610040a36001610040526020610040f35b6340c10f1981186103c2573" +
+		"46104c8576004358060a01c6104c8576008543314156104c85780156104c8576007805460243581018082116104c85790509055806005906" +
+		"100205261000052604061000020805460243581018082116104c857905090556024356100405260007fddf252ad1be2c89b69c2b068fc378" +
+		"daa952ba7f163c4a11628f55a4df523b3ef6020610040a3005b6342966c6881186103e657346104c8573361010052600435610120526104c" +
+		"661044d565b6379cc6790811861044757346104c8576004358060a01c6104c85760069061002052610000526040610000203361002052610" +
+		"0005260406100002080546024358082106104c8579003905560043561010052602435610120526104c661044d565b5b600080fd5b6101005" +
+		"1156104c85760078054610120518082106104c85790039055610100516005906100205261000052604061000020805461012051808210610" +
+		"4c8579003905561012051610040526000610100517fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206" +
+		"10040a3565b005b600080fd"
+
+	// vyperERC20SparseBytecode is laid out as vyper 0.3.10 -O gas with 5 buckets of XOR checks
+	vyperERC20SparseBytecode = "6003361161000c57610476565b60003560e01c6005810660011b6104fc01600290601e39600051565b6" +
+		"395d89b41811861005357346104f65760206100405260025461006052600354610080526060610040f35b6318160ddd81186100715734610" +
+		"4f657600754610040526020610040f35b610476565b63313ce567811861009457346104f657600454610040526020610040f35b6370a0823" +
+		"181186100cc57346104f6576004358060a01c6104f657600590610020526100005260406100002054610040526020610040f35b610476565" +
+		"b63a9059cbb811861016e57346104f6576004358060a01c6104f65733600590610020526100005260406100002080546024358082106104f" +
+		"65790039055806005906100205261000052604061000020805460243581018082116104f6579050905560243561004052337fddf252ad1be" +
+		"2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef6020610040a36001610040526020610040f35b6340c10f1981186102035" +
+		"7346104f6576004358060a01c6104f6576008543314156104f65780156104f6576007805460243581018082116104f657905090558060059" +
+		"06100205261000052604061000020805460243581018082116104f657905090556024356100405260007fddf252ad1be2c89b69c2b068fc3" +
+		"78daa952ba7f163c4a11628f55a4df523b3ef6020610040a3005b6379cc6790811861026457346104f6576004358060a01c6104f65760069" +
+		"0610020526100005260406100002033610020526100005260406100002080546024358082106104f65790039055600435610100526024356" +
+		"10120526104f461047b565b610476565b63dd62ed3e81186102ba57346104f6576004358060a01c6104f6576006906100205261000052604" +
+		"0610000206024358060a01c6104f657610020526100005260406100002054610040526020610040f35b6342966c6881186102de57346104f" +
+		"6573361010052600435610120526104f461047b565b610476565b6306fdde03811861030e57346104f657602061004052600054610060526" +
+		"00154610080526060610040f35b6323b872dd81186103f457346104f6576004358060a01c6104f657506024358060a01c6104f6575060043" +
+		"5600590610020526100005260406100002080546044358082106104f65790039055602435600590610020526100005260406100002080546" +
+		"0443581018082116104f65790509055600435600690610020526100005260406100002033610020526100005260406100002080546044358" +
+		"082106104f65790039055604435610040526024356004357fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3e" +
+		"f6020610040a36001610040526020610040f35b63095ea7b3811861047157346104f6576004358060a01c6104f6573360069061002052610" +
+		"00052604061000020816100205261000052604061000020602435905560243561004052337f8c5be1e5ebec7d5bd14f71427d1e84f3dd031" +
+		"4c0f7b2291e5b200ac8c7c3b9256020610040a36001610040526020610040f35b610476565b600080fd5b61010051156104f657600780546" +
+		"10120518082106104f657900390556101005160059061002052610000526040610000208054610120518082106104f657900390556101205" +
+		"1610040526000610100517fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef6020610040a3565b005b60008" +
+		"0fdfe0028007600d1026902e3"
+
+	// vyperERC20DenseBytecode is laid out as vyper 0.3.10 -O codesize with 3 perfect hashed buckets
+	vyperERC20DenseBytecode = "6003361161000c5761040e565b60003560e01c6003810660050261049401600590601b396000518060ff" +
+		"168160181c830260181c066007029060081c61ffff1601600790601939600051818160181c141561040e5760081c61ffff16565b3461048e" +
+		"5760206100405260005461006052600154610080526060610040f35b3461048e576020610040526002546100605260035461008052606061" +
+		"0040f35b3461048e57600454610040526020610040f35b3461048e57600754610040526020610040f35b3461048e576004358060a01c6104" +
+		"8e57600590610020526100005260406100002054610040526020610040f35b3461048e576004358060a01c61048e57600690610020526100" +
+		"00526040610000206024358060a01c61048e57610020526100005260406100002054610040526020610040f35b3461048e576004358060a0" +
+		"1c61048e57336005906100205261000052604061000020805460243580821061048e57900390558060059061002052610000526040610000" +
+		"208054602435810180821161048e579050905560243561004052337fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4d" +
+		"f523b3ef6020610040a36001610040526020610040f35b3461048e576004358060a01c61048e57506024358060a01c61048e575060043560" +
+		"05906100205261000052604061000020805460443580821061048e5790039055602435600590610020526100005260406100002080546044" +
+		"35810180821161048e5790509055600435600690610020526100005260406100002033610020526100005260406100002080546044358082" +
+		"1061048e5790039055604435610040526024356004357fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60" +
+		"20610040a36001610040526020610040f35b3461048e576004358060a01c61048e5733600690610020526100005260406100002081610020" +
+		"5261000052604061000020602435905560243561004052337f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b9" +
+		"256020610040a36001610040526020610040f35b3461048e576004358060a01c61048e5760085433141561048e57801561048e5760078054" +
+		"602435810180821161048e57905090558060059061002052610000526040610000208054602435810180821161048e579050905560243561" +
+		"00405260007fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef6020610040a3005b3461048e573361010052" +
+		"6004356101205261048c610413565b3461048e576004358060a01c61048e5760069061002052610000526040610000203361002052610000" +
+		"52604061000020805460243580821061048e5790039055600435610100526024356101205261048c610413565b600080fd5b610100511561" +
+		"048e57600780546101205180821061048e579003905561010051600590610020526100005260406100002080546101205180821061048e57" +
+		"9003905561012051610040526000610100517fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef6020610040" +
+		"a3565b005b600080fdfe000304a304000504bf04001604db04dd62ed3e00f00095d89b41007d0040c10f19031500313ce567009d0018160d" +
+		"dd00b000a9059cbb01360006fdde03005d0070a0823100c30042966c68039f0023b872dd01c800095ea7b302a30079cc679003b800"
+)
+
+func TestVyperParser_GetFunctionEntries(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		wantEntries map[string]uint64
+	}{
+		{
+			name:        "Selector in memory",
+			code:        vyperMloadBytecode,
+			wantEntries: map[string]uint64{"0xa9059cbb": 0x14, "0x70a08231": 0x4a},
+		},
+		{
+			name:        "Linear XOR dispatcher",
+			code:        vyperLinearBytecode,
+			wantEntries: map[string]uint64{"0xa9059cbb": 0x11, "0x18160ddd": 0x49},
+		},
+		{
+			name:        "Sparse selector table",
+			code:        vyperSparseBytecode,
+			wantEntries: map[string]uint64{"0xdd62ed3e": 0x27, "0xa9059cbb": 0x5a, "0x70a08231": 0x8d},
+		},
+		{
+			name:        "Dense selector table",
+			code:        vyperDenseBytecode,
+			wantEntries: map[string]uint64{"0xdd62ed3e": 0x50, "0xa9059cbb": 0x78, "0x70a08231": 0xa0},
+		},
+		{
+			name: "ERC20 - vyper 0.2",
+			code: vyperERC20MloadBytecode,
+			wantEntries: map[string]uint64{
+				"0x06fdde03": 0xb0, "0x095ea7b3": 0x3a2, "0x18160ddd": 0x13f, "0x23b872dd": 0x2af,
+				"0x313ce567": 0x118, "0x40c10f19": 0x42a, "0x42966c68": 0x4ca, "0x70a08231": 0x166,
+				"0x79cc6790": 0x4f7, "0x95d89b41": 0xe4, "0xa9059cbb": 0x207, "0xdd62ed3e": 0x1a9,
+			},
+		},
+		{
+			name: "ERC20 - vyper 0.3 linear",
+			code: vyperERC20LinearBytecode,
+			wantEntries: map[string]uint64{
+				"0x06fdde03": 0x1e, "0x095ea7b3": 0x2bc, "0x18160ddd": 0x92, "0x23b872dd": 0x1d6,
+				"0x313ce567": 0x74, "0x40c10f19": 0x339, "0x42966c68": 0x3ce, "0x70a08231": 0xb0,
+				"0x79cc6790": 0x3f2, "0x95d89b41": 0x49, "0xa9059cbb": 0x139, "0xdd62ed3e": 0xe8,
+			},
+		},
+		{
+			name: "ERC20 - vyper 0.3.10 sparse",
+			code: vyperERC20SparseBytecode,
+			wantEntries: map[string]uint64{
+				"0x06fdde03": 0x2ef, "0x095ea7b3": 0x400, "0x18160ddd": 0x5f, "0x23b872dd": 0x31a,
+				"0x313ce567": 0x82, "0x40c10f19": 0x17a, "0x42966c68": 0x2c6, "0x70a08231": 0xa0,
+				"0x79cc6790": 0x20f, "0x95d89b41": 0x34, "0xa9059cbb": 0xdd, "0xdd62ed3e": 0x275,
+			},
+		},
+		{
+			name: "ERC20 - vyper 0.3.10 dense",
+			code: vyperERC20DenseBytecode,
+			wantEntries: map[string]uint64{
+				"0x06fdde03": 0x5d, "0x095ea7b3": 0x2a3, "0x18160ddd": 0xb0, "0x23b872dd": 0x1c8,
+				"0x313ce567": 0x9d, "0x40c10f19": 0x315, "0x42966c68": 0x39f, "0x70a08231": 0xc3,
+				"0x79cc6790": 0x3b8, "0x95d89b41": 0x7d, "0xa9059cbb": 0x136, "0xdd62ed3e": 0xf0,
+			},
+		},
+		{
+			name:        "No dispatcher",
+			code:        "600035600a57600080fd5b3356",
+			wantEntries: map[string]uint64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := VyperParser{Disassembler: NewTestDisassembler(tt.code)}.GetFunctionEntries()
+			if len(got) != len(tt.wantEntries) {
+				t.Errorf("GetFunctionEntries() length does not match wanted = %v, got = %v", len(tt.wantEntries), len(got))
+			}
+			for sign, pc := range tt.wantEntries {
+				if gotPC, found := got[sign]; !found || gotPC != pc {
+					t.Errorf("GetFunctionEntries() wanted = %v at %#x, got = %#x (found = %v)", sign, pc, gotPC, found)
+				}
+			}
+		})
+	}
+}
+
+func TestVyperParser_GetEventSignatures(t *testing.T) {
+	transfer := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	approval := "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+	tests := []struct {
+		name           string
+		code           string
+		wantEventSigns []string
+	}{
+		{
+			name:           "Selector in memory",
+			code:           vyperMloadBytecode,
+			wantEventSigns: []string{transfer},
+		},
+		{
+			name:           "Dense selector table",
+			code:           vyperDenseBytecode,
+			wantEventSigns: []string{transfer, approval},
+		},
+		{
+			name:           "ERC20 - vyper 0.2",
+			code:           vyperERC20MloadBytecode,
+			wantEventSigns: []string{transfer, approval},
+		},
+		{
+			name:           "ERC20 - vyper 0.3 linear",
+			code:           vyperERC20LinearBytecode,
+			wantEventSigns: []string{transfer, approval},
+		},
+		{
+			name:           "ERC20 - vyper 0.3.10 sparse",
+			code:           vyperERC20SparseBytecode,
+			wantEventSigns: []string{transfer, approval},
+		},
+		{
+			name:           "ERC20 - vyper 0.3.10 dense",
+			code:           vyperERC20DenseBytecode,
+			wantEventSigns: []string{transfer, approval},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := VyperParser{Disassembler: NewTestDisassembler(tt.code)}.GetEventSigns()
+			if len(got.Signatures) != len(tt.wantEventSigns) {
+				t.Errorf("GetEventSigns() length does not match wanted = %v, got = %v", len(tt.wantEventSigns), len(got.Signatures))
+			}
+			for _, sign := range tt.wantEventSigns {
+				if found := got.Signatures[sign]; !found {
+					t.Errorf("GetEventSigns() wanted = %v but not found", sign)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package classifier
+
+import (
+	"github.com/arhamj/abi-extractor/pkg/asm"
+	"sort"
+)
+
+// minPartialMatch is the fraction of the functions of a standard which have to be present for a partial match
+const minPartialMatch = 0.5
+
+// Match is a standard implemented fully or partially by a contract
+type Match struct {
+	Standard string
+	// Full is set when every function and event of the standard is present
+	Full bool
+	// Matched is the number of functions and events present out of Total
+	Matched int
+	Total   int
+	// MissingFunctions and MissingEvents are the text signatures of the members which are not present
+	MissingFunctions []string
+	MissingEvents    []string
+}
+
+type Classifier struct {
+	standards  []Standard
+	interfaces []Standard
+}
+
+type ClassifierOpt func(classifier *Classifier)
+
+// WithStandards replaces the standards checked, DefaultStandards by default
+func WithStandards(standards ...Standard) ClassifierOpt {
+	return func(classifier *Classifier) {
+		classifier.standards = standards
+	}
+}
+
+// WithInterfaces replaces the standards named by their interface ID, DefaultInterfaces by default
+func WithInterfaces(interfaces ...Standard) ClassifierOpt {
+	return func(classifier *Classifier) {
+		classifier.interfaces = interfaces
+	}
+}
+
+func NewClassifier(opts ...ClassifierOpt) Classifier {
+	c := Classifier{standards: DefaultStandards, interfaces: DefaultInterfaces}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Classify compares the function and event signatures of a contract with every standard. Partial matches need at
+// least half of the functions of the standard. Events are only detected when their topic is a constant, a missing
+// event does not rule out a standard. Matches are sorted by completeness
+func (c Classifier) Classify(functions asm.FunctionSigns, events asm.EventSigns) []Match {
+	res := make([]Match, 0)
+	for _, standard := range c.standards {
+		match := Match{Standard: standard.Name, MissingFunctions: make([]string, 0), MissingEvents: make([]string, 0)}
+		matchedFunctions := 0
+		for hexSign, textSign := range standard.FunctionSigns() {
+			if functions.Signatures[hexSign] {
+				matchedFunctions++
+				continue
+			}
+			match.MissingFunctions = append(match.MissingFunctions, textSign)
+		}
+		for hexSign, textSign := range standard.EventSigns() {
+			if !events.Signatures[hexSign] {
+				match.MissingEvents = append(match.MissingEvents, textSign)
+			}
+		}
+		if matchedFunctions == 0 || float64(matchedFunctions) < minPartialMatch*float64(len(standard.Functions)) {
+			continue
+		}
+		sort.Strings(match.MissingFunctions)
+		sort.Strings(match.MissingEvents)
+		match.Total = len(standard.Functions) + len(standard.Events)
+		match.Matched = match.Total - len(match.MissingFunctions) - len(match.MissingEvents)
+		match.Full = match.Matched == match.Total
+		res = append(res, match)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Full != res[j].Full {
+			return res[i].Full
+		}
+		return res[i].Matched*res[j].Total > res[j].Matched*res[i].Total
+	})
+	return res
+}
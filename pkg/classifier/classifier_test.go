@@ -0,0 +1,114 @@
+package classifier
+
+import (
+	"github.com/arhamj/abi-extractor/pkg/asm"
+	"reflect"
+	"testing"
+)
+
+func hexSigns(standards ...Standard) []string {
+	res := make([]string, 0)
+	for _, s := range standards {
+		for hexSign := range s.FunctionSigns() {
+			res = append(res, hexSign)
+		}
+	}
+	return res
+}
+
+func TestStandard_Signs(t *testing.T) {
+	if textSign := ERC20.FunctionSigns()["0xa9059cbb"]; textSign != "transfer(address,uint256)" {
+		t.Errorf("FunctionSigns() 0xa9059cbb = %v", textSign)
+	}
+	topic := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	if textSign := ERC20.EventSigns()[topic]; textSign != "Transfer(address,address,uint256)" {
+		t.Errorf("EventSigns() %s = %v", topic, textSign)
+	}
+}
+
+func TestClassifier_Classify(t *testing.T) {
+	tests := []struct {
+		name      string
+		standards []Standard
+		functions []string
+		events    []string
+		want      []Match
+	}{
+		{
+			name:      "Full ERC-20 and partial Ownable",
+			standards: []Standard{ERC20, Ownable},
+			functions: append(hexSigns(ERC20), "0x8da5cb5b", "0xf2fde38b"),
+			events: []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+				"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"},
+			want: []Match{
+				{Standard: "ERC-20", Full: true, Matched: 8, Total: 8, MissingFunctions: []string{}, MissingEvents: []string{}},
+				{Standard: "Ownable", Matched: 2, Total: 4, MissingFunctions: []string{"renounceOwnership()"},
+					MissingEvents: []string{"OwnershipTransferred(address,address)"}},
+			},
+		},
+		{
+			name:      "Less than half of the functions",
+			standards: []Standard{ERC721},
+			functions: []string{"0x70a08231", "0x6352211e"},
+			want:      []Match{},
+		},
+		{
+			name:      "Default standards",
+			functions: hexSigns(ERC165),
+			want: []Match{
+				{Standard: "ERC-165", Full: true, Matched: 1, Total: 1, MissingFunctions: []string{}, MissingEvents: []string{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClassifier()
+			if tt.standards != nil {
+				c = NewClassifier(WithStandards(tt.standards...))
+			}
+			got := c.Classify(asm.NewFunctionSigns(tt.functions), asm.NewEventSigns(tt.events))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Classify() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandard_InterfaceID(t *testing.T) {
+	tests := []struct {
+		standard Standard
+		want     string
+	}{
+		{standard: ERC165, want: "0x01ffc9a7"},
+		{standard: ERC20, want: "0x36372b07"},
+		{standard: ERC721, want: "0x80ac58cd"},
+		{standard: ERC721Metadata, want: "0x5b5e139f"},
+		{standard: ERC721Enumerable, want: "0x780e9d63"},
+		{standard: ERC721Receiver, want: "0x150b7a02"},
+		{standard: ERC1155, want: "0xd9b67a26"},
+		{standard: ERC1155MetadataURI, want: "0x0e89341c"},
+		{standard: ERC1155Receiver, want: "0x4e2312e0"},
+		{standard: ERC2981, want: "0x2a55205a"},
+		{standard: AccessControl, want: "0x7965db0b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.standard.Name, func(t *testing.T) {
+			if got := tt.standard.InterfaceID(); got != tt.want {
+				t.Errorf("InterfaceID() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifier_Interfaces(t *testing.T) {
+	got := NewClassifier().Interfaces([]string{"0x80ac58cd", "0x01ffc9a7", "0x49064906", "0x12345678"})
+	want := []SupportedInterface{
+		{ID: "0x01ffc9a7", Name: "ERC-165"},
+		{ID: "0x12345678"},
+		{ID: "0x49064906", Name: "ERC-4906"},
+		{ID: "0x80ac58cd", Name: "ERC-721"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Interfaces() got = %+v, want %+v", got, want)
+	}
+}
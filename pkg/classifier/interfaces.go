@@ -0,0 +1,82 @@
+package classifier
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/crypto"
+	"sort"
+)
+
+// SupportedInterface is an interface ID reported by supportsInterface, Name is empty when the ID is not well known
+type SupportedInterface struct {
+	ID   string
+	Name string
+}
+
+// InterfaceID returns the ERC-165 interface ID prefixed with 0x, the XOR of the selectors of the functions of the
+// standard
+//
+//	Ref: https://eips.ethereum.org/EIPS/eip-165#how-interfaces-are-identified
+func (s Standard) InterfaceID() string {
+	var id uint32
+	for _, textSign := range s.Functions {
+		selector := crypto.Keccak256([]byte(textSign))[:4]
+		id ^= uint32(selector[0])<<24 | uint32(selector[1])<<16 | uint32(selector[2])<<8 | uint32(selector[3])
+	}
+	return fmt.Sprintf("0x%08x", id)
+}
+
+var (
+	ERC721Metadata = Standard{
+		Name:      "ERC-721 Metadata",
+		Functions: []string{"name()", "symbol()", "tokenURI(uint256)"},
+	}
+	ERC721Enumerable = Standard{
+		Name:      "ERC-721 Enumerable",
+		Functions: []string{"totalSupply()", "tokenOfOwnerByIndex(address,uint256)", "tokenByIndex(uint256)"},
+	}
+	ERC721Receiver = Standard{
+		Name:      "ERC-721 TokenReceiver",
+		Functions: []string{"onERC721Received(address,address,uint256,bytes)"},
+	}
+	ERC1155MetadataURI = Standard{
+		Name:      "ERC-1155 Metadata URI",
+		Functions: []string{"uri(uint256)"},
+	}
+	ERC1155Receiver = Standard{
+		Name: "ERC-1155 TokenReceiver",
+		Functions: []string{"onERC1155Received(address,address,uint256,uint256,bytes)",
+			"onERC1155BatchReceived(address,address,uint256[],uint256[],bytes)"},
+	}
+	ERC2981 = Standard{
+		Name:      "ERC-2981",
+		Functions: []string{"royaltyInfo(uint256,uint256)"},
+	}
+
+	// DefaultInterfaces are the standards named by NewClassifier when reported by supportsInterface
+	DefaultInterfaces = []Standard{ERC165, ERC20, ERC721, ERC721Metadata, ERC721Enumerable, ERC721Receiver, ERC1155,
+		ERC1155MetadataURI, ERC1155Receiver, ERC2981, AccessControl}
+
+	// namedInterfaceIDs are interface IDs which are not derived from functions
+	namedInterfaceIDs = map[string]string{
+		// ERC-4906 has only events, the ID is fixed by the standard
+		"0x49064906": "ERC-4906",
+	}
+)
+
+// Interfaces names the interface IDs found in the supportsInterface body, see asm.InterfaceIDs. The result is sorted
+// by ID
+func (c Classifier) Interfaces(ids []string) []SupportedInterface {
+	names := make(map[string]string, len(c.interfaces)+len(namedInterfaceIDs))
+	for id, name := range namedInterfaceIDs {
+		names[id] = name
+	}
+	for _, standard := range c.interfaces {
+		names[standard.InterfaceID()] = standard.Name
+	}
+	res := make([]SupportedInterface, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, SupportedInterface{ID: id, Name: names[id]})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
@@ -0,0 +1,105 @@
+package classifier
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Standard is a set of functions and events identified by their text signature
+type Standard struct {
+	Name      string
+	Functions []string
+	Events    []string
+}
+
+// FunctionSigns returns the map of function signature prefixed with 0x to its text signature
+func (s Standard) FunctionSigns() map[string]string {
+	res := make(map[string]string, len(s.Functions))
+	for _, textSign := range s.Functions {
+		res[hexutil.Encode(crypto.Keccak256([]byte(textSign))[:4])] = textSign
+	}
+	return res
+}
+
+// EventSigns returns the map of event signature prefixed with 0x to its text signature
+func (s Standard) EventSigns() map[string]string {
+	res := make(map[string]string, len(s.Events))
+	for _, textSign := range s.Events {
+		res[hexutil.Encode(crypto.Keccak256([]byte(textSign)))] = textSign
+	}
+	return res
+}
+
+var (
+	ERC20 = Standard{
+		Name: "ERC-20",
+		Functions: []string{"totalSupply()", "balanceOf(address)", "transfer(address,uint256)",
+			"transferFrom(address,address,uint256)", "approve(address,uint256)", "allowance(address,address)"},
+		Events: []string{"Transfer(address,address,uint256)", "Approval(address,address,uint256)"},
+	}
+	ERC721 = Standard{
+		Name: "ERC-721",
+		Functions: []string{"balanceOf(address)", "ownerOf(uint256)", "safeTransferFrom(address,address,uint256,bytes)",
+			"safeTransferFrom(address,address,uint256)", "transferFrom(address,address,uint256)", "approve(address,uint256)",
+			"setApprovalForAll(address,bool)", "getApproved(uint256)", "isApprovedForAll(address,address)"},
+		Events: []string{"Transfer(address,address,uint256)", "Approval(address,address,uint256)",
+			"ApprovalForAll(address,address,bool)"},
+	}
+	ERC1155 = Standard{
+		Name: "ERC-1155",
+		Functions: []string{"safeTransferFrom(address,address,uint256,uint256,bytes)",
+			"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)", "balanceOf(address,uint256)",
+			"balanceOfBatch(address[],uint256[])", "setApprovalForAll(address,bool)", "isApprovedForAll(address,address)"},
+		Events: []string{"TransferSingle(address,address,address,uint256,uint256)",
+			"TransferBatch(address,address,address,uint256[],uint256[])", "ApprovalForAll(address,address,bool)",
+			"URI(string,uint256)"},
+	}
+	ERC4626 = Standard{
+		Name: "ERC-4626",
+		Functions: []string{"asset()", "totalAssets()", "convertToShares(uint256)", "convertToAssets(uint256)",
+			"maxDeposit(address)", "previewDeposit(uint256)", "deposit(uint256,address)", "maxMint(address)",
+			"previewMint(uint256)", "mint(uint256,address)", "maxWithdraw(address)", "previewWithdraw(uint256)",
+			"withdraw(uint256,address,address)", "maxRedeem(address)", "previewRedeem(uint256)",
+			"redeem(uint256,address,address)"},
+		Events: []string{"Deposit(address,address,uint256,uint256)", "Withdraw(address,address,address,uint256,uint256)"},
+	}
+	ERC777 = Standard{
+		Name: "ERC-777",
+		Functions: []string{"name()", "symbol()", "totalSupply()", "balanceOf(address)", "granularity()",
+			"defaultOperators()", "isOperatorFor(address,address)", "authorizeOperator(address)",
+			"revokeOperator(address)", "send(address,uint256,bytes)", "operatorSend(address,address,uint256,bytes,bytes)",
+			"burn(uint256,bytes)", "operatorBurn(address,uint256,bytes,bytes)"},
+		Events: []string{"Sent(address,address,address,uint256,bytes,bytes)", "Minted(address,address,uint256,bytes,bytes)",
+			"Burned(address,address,uint256,bytes,bytes)", "AuthorizedOperator(address,address)",
+			"RevokedOperator(address,address)"},
+	}
+	ERC2612 = Standard{
+		Name:      "ERC-2612",
+		Functions: []string{"permit(address,address,uint256,uint256,uint8,bytes32,bytes32)", "nonces(address)", "DOMAIN_SEPARATOR()"},
+	}
+	ERC165 = Standard{
+		Name:      "ERC-165",
+		Functions: []string{"supportsInterface(bytes4)"},
+	}
+	Ownable = Standard{
+		Name:      "Ownable",
+		Functions: []string{"owner()", "transferOwnership(address)", "renounceOwnership()"},
+		Events:    []string{"OwnershipTransferred(address,address)"},
+	}
+	AccessControl = Standard{
+		Name: "AccessControl",
+		Functions: []string{"hasRole(bytes32,address)", "getRoleAdmin(bytes32)", "grantRole(bytes32,address)",
+			"revokeRole(bytes32,address)", "renounceRole(bytes32,address)"},
+		Events: []string{"RoleGranted(bytes32,address,address)", "RoleRevoked(bytes32,address,address)",
+			"RoleAdminChanged(bytes32,bytes32,bytes32)"},
+	}
+	Pausable = Standard{
+		Name:      "Pausable",
+		Functions: []string{"paused()"},
+		Events:    []string{"Paused(address)", "Unpaused(address)"},
+	}
+
+	// DefaultStandards are the standards checked by NewClassifier
+	DefaultStandards = []Standard{ERC20, ERC721, ERC1155, ERC4626, ERC777, ERC2612, ERC165, Ownable, AccessControl,
+		Pausable}
+)
@@ -2,6 +2,7 @@ package external
 
 import (
 	"errors"
+	"fmt"
 	"github.com/go-resty/resty/v2"
 	"go.uber.org/zap"
 )
@@ -17,16 +18,100 @@ type ChainGateway struct {
 }
 
 type EthReq struct {
-	Jsonrpc string   `json:"jsonrpc"`
-	Method  string   `json:"method"`
-	Params  []string `json:"params"`
-	Id      int      `json:"id"`
+	Jsonrpc string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	Id      int           `json:"id"`
 }
 
 type EthCodeResp struct {
 	Result string `json:"result"`
 }
 
+type EthStorageResp struct {
+	Result string `json:"result"`
+}
+
+// EthCallMsg is the transaction call object of eth_call
+type EthCallMsg struct {
+	To   string `json:"to"`
+	Data string `json:"data"`
+}
+
+type EthCallResp struct {
+	Result string `json:"result"`
+}
+
+// EthLog is a log emitted by a transaction as found in the logs of a receipt, topics and data are hex prefixed with 0x
+type EthLog struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// EthTransaction is the transaction object of eth_getTransactionByHash, To is empty for a contract creation
+type EthTransaction struct {
+	Hash        string `json:"hash"`
+	BlockNumber string `json:"blockNumber"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Value       string `json:"value"`
+	Input       string `json:"input"`
+}
+
+// EthTransactionResp Result is nil when the transaction is not known by the node
+type EthTransactionResp struct {
+	Result *EthTransaction `json:"result"`
+}
+
+// EthReceipt is the receipt object of eth_getTransactionReceipt, Status is 0x1 on success and 0x0 on failure
+type EthReceipt struct {
+	TransactionHash string   `json:"transactionHash"`
+	Status          string   `json:"status"`
+	ContractAddress string   `json:"contractAddress"`
+	GasUsed         string   `json:"gasUsed"`
+	Logs            []EthLog `json:"logs"`
+}
+
+// EthReceiptResp Result is nil when the transaction is not known by the node or still pending
+type EthReceiptResp struct {
+	Result *EthReceipt `json:"result"`
+}
+
+// EthCallFrame is a call frame of the callTracer of debug_traceTransaction, Calls are the frames of the nested calls
+// and Logs are only present when the tracer is configured with withLog
+type EthCallFrame struct {
+	Type         string         `json:"type"`
+	From         string         `json:"from"`
+	To           string         `json:"to"`
+	Value        string         `json:"value"`
+	Gas          string         `json:"gas"`
+	GasUsed      string         `json:"gasUsed"`
+	Input        string         `json:"input"`
+	Output       string         `json:"output"`
+	Error        string         `json:"error"`
+	RevertReason string         `json:"revertReason"`
+	Calls        []EthCallFrame `json:"calls"`
+	Logs         []EthLog       `json:"logs"`
+}
+
+// EthRPCError is the error object of a JSON-RPC response, e.g. code -32601 when the node does not expose the method
+type EthRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *EthRPCError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// EthTraceResp Result is the top level call frame, nil when the transaction is not known by the node or when the node
+// answered with Error
+type EthTraceResp struct {
+	Result *EthCallFrame `json:"result"`
+	Error  *EthRPCError  `json:"error"`
+}
+
 type ChainGatewayOpt func(gateway *ChainGateway)
 
 func WithEthEndpoint(endpoint string) func(gateway *ChainGateway) {
@@ -51,7 +136,7 @@ func (g ChainGateway) EthGetCode(contract string) (*EthCodeResp, error) {
 	req := EthReq{
 		Jsonrpc: "2.0",
 		Method:  "eth_getCode",
-		Params:  []string{contract, "latest"},
+		Params:  []interface{}{contract, "latest"},
 		Id:      1,
 	}
 	resp, err := g.httpclient.R().
@@ -65,3 +150,116 @@ func (g ChainGateway) EthGetCode(contract string) (*EthCodeResp, error) {
 	}
 	return resp.Result().(*EthCodeResp), nil
 }
+
+// EthGetStorageAt returns the 32 bytes word stored at slot, slot is in hex prefixed with 0x
+func (g ChainGateway) EthGetStorageAt(contract string, slot string) (*EthStorageResp, error) {
+	req := EthReq{
+		Jsonrpc: "2.0",
+		Method:  "eth_getStorageAt",
+		Params:  []interface{}{contract, slot, "latest"},
+		Id:      1,
+	}
+	resp, err := g.httpclient.R().
+		SetBody(req).
+		SetHeader("Accept", "application/json").
+		SetResult(&EthStorageResp{}).
+		Post(g.ethEndpoint)
+	if err != nil {
+		g.logger.Error("EthGetStorageAt: error making RPC call", zap.String("contract", contract),
+			zap.String("slot", slot), zap.Error(err))
+		return nil, errors.New("error when fetching storage for contract")
+	}
+	return resp.Result().(*EthStorageResp), nil
+}
+
+// EthCall executes a read only call of the contract at the latest block, data is the hex calldata prefixed with 0x
+func (g ChainGateway) EthCall(contract string, data string) (*EthCallResp, error) {
+	req := EthReq{
+		Jsonrpc: "2.0",
+		Method:  "eth_call",
+		Params:  []interface{}{EthCallMsg{To: contract, Data: data}, "latest"},
+		Id:      1,
+	}
+	resp, err := g.httpclient.R().
+		SetBody(req).
+		SetHeader("Accept", "application/json").
+		SetResult(&EthCallResp{}).
+		Post(g.ethEndpoint)
+	if err != nil {
+		g.logger.Error("EthCall: error making RPC call", zap.String("contract", contract),
+			zap.String("data", data), zap.Error(err))
+		return nil, errors.New("error when calling contract")
+	}
+	return resp.Result().(*EthCallResp), nil
+}
+
+// EthGetTransactionByHash returns the transaction with the given hash prefixed with 0x
+func (g ChainGateway) EthGetTransactionByHash(hash string) (*EthTransactionResp, error) {
+	req := EthReq{
+		Jsonrpc: "2.0",
+		Method:  "eth_getTransactionByHash",
+		Params:  []interface{}{hash},
+		Id:      1,
+	}
+	resp, err := g.httpclient.R().
+		SetBody(req).
+		SetHeader("Accept", "application/json").
+		SetResult(&EthTransactionResp{}).
+		Post(g.ethEndpoint)
+	if err != nil {
+		g.logger.Error("EthGetTransactionByHash: error making RPC call", zap.String("hash", hash), zap.Error(err))
+		return nil, errors.New("error when fetching transaction")
+	}
+	return resp.Result().(*EthTransactionResp), nil
+}
+
+// EthGetTransactionReceipt returns the receipt, including the logs, of the transaction with the given hash
+func (g ChainGateway) EthGetTransactionReceipt(hash string) (*EthReceiptResp, error) {
+	req := EthReq{
+		Jsonrpc: "2.0",
+		Method:  "eth_getTransactionReceipt",
+		Params:  []interface{}{hash},
+		Id:      1,
+	}
+	resp, err := g.httpclient.R().
+		SetBody(req).
+		SetHeader("Accept", "application/json").
+		SetResult(&EthReceiptResp{}).
+		Post(g.ethEndpoint)
+	if err != nil {
+		g.logger.Error("EthGetTransactionReceipt: error making RPC call", zap.String("hash", hash), zap.Error(err))
+		return nil, errors.New("error when fetching transaction receipt")
+	}
+	return resp.Result().(*EthReceiptResp), nil
+}
+
+// DebugTraceTransaction replays the transaction with the callTracer and returns the tree of call frames including
+// the logs. The node has to expose the debug namespace
+//
+//	Ref: https://geth.ethereum.org/docs/developers/evm-tracing/built-in-tracers#call-tracer
+func (g ChainGateway) DebugTraceTransaction(hash string) (*EthTraceResp, error) {
+	req := EthReq{
+		Jsonrpc: "2.0",
+		Method:  "debug_traceTransaction",
+		Params: []interface{}{hash, map[string]interface{}{
+			"tracer":       "callTracer",
+			"tracerConfig": map[string]interface{}{"withLog": true},
+		}},
+		Id: 1,
+	}
+	resp, err := g.httpclient.R().
+		SetBody(req).
+		SetHeader("Accept", "application/json").
+		SetResult(&EthTraceResp{}).
+		Post(g.ethEndpoint)
+	if err != nil {
+		g.logger.Error("DebugTraceTransaction: error making RPC call", zap.String("hash", hash), zap.Error(err))
+		return nil, errors.New("error when tracing transaction")
+	}
+	res := resp.Result().(*EthTraceResp)
+	if res.Error != nil {
+		g.logger.Error("DebugTraceTransaction: node returned an error", zap.String("hash", hash), zap.Error(res.Error))
+		return nil, res.Error
+	}
+	return res, nil
+}
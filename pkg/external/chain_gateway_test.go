@@ -1,7 +1,10 @@
 package external
 
 import (
+	"encoding/json"
 	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -46,3 +49,193 @@ func TestChainGateway_EthGetCode(t *testing.T) {
 		})
 	}
 }
+
+// newTestNode returns a JSON-RPC server answering with result and recording the last request
+func newTestNode(t *testing.T, result string, got *EthReq) *httptest.Server {
+	return newTestNodeJSON(t, `"`+result+`"`, got)
+}
+
+// newTestNodeJSON returns a JSON-RPC server answering with the raw JSON result and recording the last request
+func newTestNodeJSON(t *testing.T, result string, got *EthReq) *httptest.Server {
+	return newTestNodeResponse(t, `"result":`+result, got)
+}
+
+// newTestNodeResponse returns a JSON-RPC server answering with the raw result or error member and recording the last
+// request
+func newTestNodeResponse(t *testing.T, member string, got *EthReq) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("invalid JSON-RPC request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,` + member + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestChainGateway_EthGetStorageAt(t *testing.T) {
+	slot := "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+	word := "0x000000000000000000000000a2327a938febf5fec13bacfb16ae10ecbc4cbdcf"
+	var req EthReq
+	server := newTestNode(t, word, &req)
+	g := NewChainGatewayWithOpts(WithEthEndpoint(server.URL))
+	got, err := g.EthGetStorageAt("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", slot)
+	if err != nil {
+		t.Fatalf("EthGetStorageAt() error = %v", err)
+	}
+	if got.Result != word {
+		t.Errorf("EthGetStorageAt() got = %v, want %v", got.Result, word)
+	}
+	wantParams := []interface{}{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", slot, "latest"}
+	if req.Method != "eth_getStorageAt" || !reflect.DeepEqual(req.Params, wantParams) {
+		t.Errorf("EthGetStorageAt() request = %+v", req)
+	}
+}
+
+func TestChainGateway_EthCall(t *testing.T) {
+	result := "0x0000000000000000000000000000000000000000000000000000000000000020"
+	var req EthReq
+	server := newTestNode(t, result, &req)
+	g := NewChainGatewayWithOpts(WithEthEndpoint(server.URL))
+	got, err := g.EthCall("0x1111111111111111111111111111111111111111", "0x7a0ed627")
+	if err != nil {
+		t.Fatalf("EthCall() error = %v", err)
+	}
+	if got.Result != result {
+		t.Errorf("EthCall() got = %v, want %v", got.Result, result)
+	}
+	wantParams := []interface{}{
+		map[string]interface{}{"to": "0x1111111111111111111111111111111111111111", "data": "0x7a0ed627"},
+		"latest",
+	}
+	if req.Method != "eth_call" || !reflect.DeepEqual(req.Params, wantParams) {
+		t.Errorf("EthCall() request = %+v", req)
+	}
+}
+
+func TestChainGateway_EthGetTransactionByHash(t *testing.T) {
+	hash := "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"
+	tests := []struct {
+		name   string
+		result string
+		want   *EthTransaction
+	}{
+		{
+			name: "Transaction found",
+			result: `{"hash":"` + hash + `","blockNumber":"0x10","from":"0x1111111111111111111111111111111111111111",` +
+				`"to":"0x2222222222222222222222222222222222222222","value":"0x0","input":"0xa9059cbb","gas":"0x5208"}`,
+			want: &EthTransaction{Hash: hash, BlockNumber: "0x10", From: "0x1111111111111111111111111111111111111111",
+				To: "0x2222222222222222222222222222222222222222", Value: "0x0", Input: "0xa9059cbb"},
+		},
+		{
+			name:   "Transaction not found",
+			result: "null",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req EthReq
+			server := newTestNodeJSON(t, tt.result, &req)
+			g := NewChainGatewayWithOpts(WithEthEndpoint(server.URL))
+			got, err := g.EthGetTransactionByHash(hash)
+			if err != nil {
+				t.Fatalf("EthGetTransactionByHash() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Result, tt.want) {
+				t.Errorf("EthGetTransactionByHash() got = %+v, want %+v", got.Result, tt.want)
+			}
+			if req.Method != "eth_getTransactionByHash" || !reflect.DeepEqual(req.Params, []interface{}{hash}) {
+				t.Errorf("EthGetTransactionByHash() request = %+v", req)
+			}
+		})
+	}
+}
+
+func TestChainGateway_EthGetTransactionReceipt(t *testing.T) {
+	hash := "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"
+	result := `{"transactionHash":"` + hash + `","status":"0x1","contractAddress":null,"gasUsed":"0xb411",` +
+		`"logs":[{"address":"0x2222222222222222222222222222222222222222","topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],` +
+		`"data":"0x","logIndex":"0x0","removed":false}]}`
+	var req EthReq
+	server := newTestNodeJSON(t, result, &req)
+	g := NewChainGatewayWithOpts(WithEthEndpoint(server.URL))
+	got, err := g.EthGetTransactionReceipt(hash)
+	if err != nil {
+		t.Fatalf("EthGetTransactionReceipt() error = %v", err)
+	}
+	want := &EthReceipt{TransactionHash: hash, Status: "0x1", GasUsed: "0xb411", Logs: []EthLog{{
+		Address: "0x2222222222222222222222222222222222222222",
+		Topics:  []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+		Data:    "0x",
+	}}}
+	if !reflect.DeepEqual(got.Result, want) {
+		t.Errorf("EthGetTransactionReceipt() got = %+v, want %+v", got.Result, want)
+	}
+	if req.Method != "eth_getTransactionReceipt" || !reflect.DeepEqual(req.Params, []interface{}{hash}) {
+		t.Errorf("EthGetTransactionReceipt() request = %+v", req)
+	}
+}
+
+func TestChainGateway_DebugTraceTransaction(t *testing.T) {
+	hash := "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"
+	result := `{"type":"CALL","from":"0x1111111111111111111111111111111111111111","to":"0x2222222222222222222222222222222222222222",` +
+		`"value":"0x0","gas":"0x1","gasUsed":"0x1","input":"0x","output":"0x","calls":[{"type":"STATICCALL",` +
+		`"from":"0x2222222222222222222222222222222222222222","to":"0x3333333333333333333333333333333333333333",` +
+		`"input":"0x18160ddd","error":"execution reverted","revertReason":"paused"}]}`
+	var req EthReq
+	server := newTestNodeJSON(t, result, &req)
+	g := NewChainGatewayWithOpts(WithEthEndpoint(server.URL))
+	got, err := g.DebugTraceTransaction(hash)
+	if err != nil {
+		t.Fatalf("DebugTraceTransaction() error = %v", err)
+	}
+	want := &EthCallFrame{Type: "CALL", From: "0x1111111111111111111111111111111111111111",
+		To: "0x2222222222222222222222222222222222222222", Value: "0x0", Gas: "0x1", GasUsed: "0x1", Input: "0x",
+		Output: "0x", Calls: []EthCallFrame{{Type: "STATICCALL", From: "0x2222222222222222222222222222222222222222",
+			To: "0x3333333333333333333333333333333333333333", Input: "0x18160ddd", Error: "execution reverted",
+			RevertReason: "paused"}}}
+	if !reflect.DeepEqual(got.Result, want) {
+		t.Errorf("DebugTraceTransaction() got = %+v, want %+v", got.Result, want)
+	}
+	wantParams := []interface{}{hash, map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	}}
+	if req.Method != "debug_traceTransaction" || !reflect.DeepEqual(req.Params, wantParams) {
+		t.Errorf("DebugTraceTransaction() request = %+v", req)
+	}
+}
+
+func TestChainGateway_DebugTraceTransactionError(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  string
+		wantErr error
+	}{
+		{
+			name:    "Unknown transaction",
+			member:  `"result":null`,
+			wantErr: nil,
+		},
+		{
+			name:    "Debug namespace not exposed",
+			member:  `"error":{"code":-32601,"message":"the method debug_traceTransaction does not exist/is not available"}`,
+			wantErr: &EthRPCError{Code: -32601, Message: "the method debug_traceTransaction does not exist/is not available"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req EthReq
+			server := newTestNodeResponse(t, tt.member, &req)
+			g := NewChainGatewayWithOpts(WithEthEndpoint(server.URL))
+			got, err := g.DebugTraceTransaction("0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060")
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("DebugTraceTransaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Result != nil {
+				t.Errorf("DebugTraceTransaction() got = %+v, want no result", got.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"strings"
+)
+
+const (
+	ABIFunction = "function"
+	ABIEvent    = "event"
+	ABIError    = "error"
+)
+
+// ABIEntry is a single item of a Solidity JSON ABI
+//
+//	Ref: https://docs.soliditylang.org/en/latest/abi-spec.html#json
+type ABIEntry struct {
+	Type            string        `json:"type"`
+	Name            string        `json:"name"`
+	Inputs          []ABIArgument `json:"inputs"`
+	Outputs         []ABIArgument `json:"outputs"`
+	StateMutability string        `json:"stateMutability,omitempty"`
+	Anonymous       *bool         `json:"anonymous,omitempty"`
+}
+
+// MarshalJSON omits nil outputs, which are unknown, and keeps the empty outputs of a function which returns nothing
+func (e ABIEntry) MarshalJSON() ([]byte, error) {
+	type entry ABIEntry
+	if e.Outputs != nil {
+		return json.Marshal(entry(e))
+	}
+	return json.Marshal(struct {
+		entry
+		Outputs []ABIArgument `json:"outputs,omitempty"`
+	}{entry: entry(e)})
+}
+
+type ABIArgument struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Components []ABIArgument `json:"components,omitempty"`
+	Indexed    bool          `json:"indexed,omitempty"`
+	// Inferred is set when the type, or for an event input whether it is indexed, is guessed from the bytecode rather
+	// than decoded from a text signature
+	Inferred bool `json:"inferred,omitempty"`
+}
+
+// ParseTextSignature converts a text signature like transfer(address,uint256) into the name and the ABI inputs
+func ParseTextSignature(textSign string) (name string, inputs []ABIArgument, err error) {
+	// ParseSelector indexes into the remaining input without bound checks for some malformed signatures
+	defer func() {
+		if r := recover(); r != nil {
+			name, inputs, err = "", nil, fmt.Errorf("failed to parse selector '%s': %v", textSign, r)
+		}
+	}()
+	selector, err := abi.ParseSelector(strings.ReplaceAll(textSign, " ", ""))
+	if err != nil {
+		return "", nil, err
+	}
+	inputs = toABIArguments(selector.Inputs)
+	// ParseSelector does not validate elementary types, abi.NewType does
+	if _, err = ToArguments(inputs); err != nil {
+		return "", nil, err
+	}
+	return selector.Name, inputs, nil
+}
+
+// ToArguments converts the ABI arguments into go-ethereum arguments which can be used for packing and unpacking
+func ToArguments(args []ABIArgument) (abi.Arguments, error) {
+	res := make(abi.Arguments, 0, len(args))
+	for _, arg := range args {
+		typ, err := abi.NewType(arg.Type, "", toArgumentMarshaling(arg.Components))
+		if err != nil {
+			return nil, fmt.Errorf("invalid type %s: %w", arg.Type, err)
+		}
+		res = append(res, abi.Argument{Name: arg.Name, Type: typ, Indexed: arg.Indexed})
+	}
+	return res, nil
+}
+
+func toABIArguments(args []abi.ArgumentMarshaling) []ABIArgument {
+	res := make([]ABIArgument, 0, len(args))
+	for _, arg := range args {
+		res = append(res, ABIArgument{
+			Name:       arg.Name,
+			Type:       arg.Type,
+			Components: toABIArguments(arg.Components),
+		})
+	}
+	return res
+}
+
+func toArgumentMarshaling(args []ABIArgument) []abi.ArgumentMarshaling {
+	if len(args) == 0 {
+		return nil
+	}
+	res := make([]abi.ArgumentMarshaling, 0, len(args))
+	for _, arg := range args {
+		res = append(res, abi.ArgumentMarshaling{
+			Name:       arg.Name,
+			Type:       arg.Type,
+			Components: toArgumentMarshaling(arg.Components),
+			Indexed:    arg.Indexed,
+		})
+	}
+	return res
+}
+
+// newFunctionEntry builds the ABI entry for a function selector, textSign can be empty when the selector is unresolved
+func newFunctionEntry(hexSign string, textSign string) ABIEntry {
+	if textSign != "" {
+		name, inputs, err := ParseTextSignature(textSign)
+		if err == nil {
+			return ABIEntry{Type: ABIFunction, Name: name, Inputs: inputs, Outputs: []ABIArgument{}}
+		}
+	}
+	return ABIEntry{Type: ABIFunction, Name: placeholderName(hexSign), Inputs: []ABIArgument{}, Outputs: []ABIArgument{}}
+}
+
+// newEventEntry builds the ABI entry for an event topic, textSign can be empty when the topic is unresolved
+func newEventEntry(hexSign string, textSign string) ABIEntry {
+	anonymous := false
+	if textSign != "" {
+		name, inputs, err := ParseTextSignature(textSign)
+		if err == nil {
+			return ABIEntry{Type: ABIEvent, Name: name, Inputs: inputs, Anonymous: &anonymous}
+		}
+	}
+	return ABIEntry{Type: ABIEvent, Name: placeholderName(hexSign), Inputs: []ABIArgument{}, Anonymous: &anonymous}
+}
+
+// newErrorEntry builds the ABI entry for a custom error selector, textSign can be empty when the selector is unresolved
+func newErrorEntry(hexSign string, textSign string) ABIEntry {
+	if textSign != "" {
+		name, inputs, err := ParseTextSignature(textSign)
+		if err == nil {
+			return ABIEntry{Type: ABIError, Name: name, Inputs: inputs}
+		}
+	}
+	return ABIEntry{Type: ABIError, Name: placeholderName(hexSign), Inputs: []ABIArgument{}}
+}
+
+// newInferredArguments builds unnamed arguments of the types inferred from the bytecode, nil when they are unknown
+func newInferredArguments(types []string) []ABIArgument {
+	if types == nil {
+		return nil
+	}
+	res := make([]ABIArgument, 0, len(types))
+	for _, typ := range types {
+		res = append(res, ABIArgument{Type: typ, Inferred: true})
+	}
+	return res
+}
+
+// placeholderName names an unresolved selector so that it is still present in the ABI, for e.g. unknown_a9059cbb
+func placeholderName(hexSign string) string {
+	return "unknown_" + strings.TrimPrefix(hexSign, "0x")
+}
+
+// unpackStrict unpacks the data and rejects it unless packing the values gives back the same bytes. Unpack alone
+// ignores trailing bytes and dirty padding, which lets a wrong signature decode the data of a colliding selector
+func unpackStrict(args abi.Arguments, data []byte) ([]interface{}, error) {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	packed, err := args.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(packed, data) {
+		return nil, errors.New("data is not the canonical encoding of the arguments")
+	}
+	return values, nil
+}
@@ -1,9 +1,13 @@
 package service
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/arhamj/abi-extractor/pkg/asm"
 	"github.com/arhamj/abi-extractor/pkg/external"
 	"go.uber.org/zap"
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -34,12 +38,20 @@ func (b BytecodeService) GetEventSigns(bytecodeParser asm.BytecodeParser) asm.Ev
 	return bytecodeParser.GetEventSigns()
 }
 
+func (b BytecodeService) GetErrorSigns(bytecodeParser asm.BytecodeParser) asm.ErrorSigns {
+	return bytecodeParser.GetErrorSigns()
+}
+
 func (b BytecodeService) GetDecodedFunctionSigns(bytecodeParser asm.BytecodeParser) map[string]string {
+	return b.decodeFunctionSigns(bytecodeParser.GetFunctionSigns().List())
+}
+
+// decodeFunctionSigns returns the verified text signature of each of the function signs which could be decoded
+func (b BytecodeService) decodeFunctionSigns(signs []string) map[string]string {
 	res := make(map[string]string, 0)
 	writeLock := new(sync.Mutex)
-	signs := bytecodeParser.GetFunctionSigns()
 	wg := new(sync.WaitGroup)
-	for sign := range signs.Signatures {
+	for _, sign := range signs {
 		wg.Add(1)
 		go func(sign string) {
 			defer wg.Done()
@@ -57,6 +69,25 @@ func (b BytecodeService) GetDecodedFunctionSigns(bytecodeParser asm.BytecodePars
 	return res
 }
 
+// GetDecodedErrorSigns resolves the custom error selectors, errors share the namespace of the function selectors
+func (b BytecodeService) GetDecodedErrorSigns(bytecodeParser asm.BytecodeParser) map[string]string {
+	return b.decodeFunctionSigns(bytecodeParser.GetErrorSigns().List())
+}
+
+// InferFunctionSigns returns an unverified text signature, for e.g. unknown_a9059cbb(address,uint256), built from the
+// argument types inferred from the bytecode for every function sign missing from decoded, see GetDecodedFunctionSigns
+func (b BytecodeService) InferFunctionSigns(signs []string, functions map[string]asm.Function,
+	decoded map[string]string) map[string]string {
+	res := make(map[string]string, 0)
+	for _, sign := range signs {
+		if _, ok := decoded[sign]; ok {
+			continue
+		}
+		res[sign] = fmt.Sprintf("%s(%s)", placeholderName(sign), strings.Join(functions[sign].Arguments, ","))
+	}
+	return res
+}
+
 func (b BytecodeService) GetDecodedEventSigns(bytecodeParser asm.BytecodeParser) map[string]string {
 	res := make(map[string]string, 0)
 	writeLock := new(sync.Mutex)
@@ -80,6 +111,66 @@ func (b BytecodeService) GetDecodedEventSigns(bytecodeParser asm.BytecodeParser)
 	return res
 }
 
+// GetABI generates the JSON ABI from the decoded function, event and custom error signatures. Selectors which could not be
+// decoded are added as placeholder entries named unknown_<hex>, with the inferred inputs for functions. The outputs
+// and the state mutability of functions are always inferred, inferred arguments are marked with "inferred": true.
+// The outputs of a function are left out when they could not be inferred. The indexed inputs of an event are inferred
+// from the number of topics of the LOG instruction and are assumed to be the leading ones, no input is marked as
+// indexed when the number of topics is unknown
 func (b BytecodeService) GetABI(bytecodeParser asm.BytecodeParser) string {
-	panic("Implement me!")
+	entries := b.GetABIEntries(bytecodeParser)
+	res, err := json.Marshal(entries)
+	if err != nil {
+		b.logger.Error("GetABI: error marshalling abi entries", zap.Error(err))
+		return "[]"
+	}
+	return string(res)
+}
+
+// GetABIEntries returns the ABI entries sorted by type and signature
+func (b BytecodeService) GetABIEntries(bytecodeParser asm.BytecodeParser) []ABIEntry {
+	entries := make([]ABIEntry, 0)
+
+	functionSigns := b.GetFunctionSigns(bytecodeParser).List()
+	sort.Strings(functionSigns)
+	decodedFunctions := b.GetDecodedFunctionSigns(bytecodeParser)
+	functions := bytecodeParser.GetFunctions()
+	inferredFunctions := b.InferFunctionSigns(functionSigns, functions, decodedFunctions)
+	for _, sign := range functionSigns {
+		textSign, decoded := decodedFunctions[sign]
+		if !decoded {
+			textSign = inferredFunctions[sign]
+		}
+		entry := newFunctionEntry(sign, textSign)
+		for i := range entry.Inputs {
+			entry.Inputs[i].Inferred = !decoded
+		}
+		entry.Outputs = newInferredArguments(functions[sign].Outputs)
+		entry.StateMutability = string(functions[sign].StateMutability)
+		entries = append(entries, entry)
+	}
+
+	events := b.GetEventSigns(bytecodeParser)
+	eventSigns := events.List()
+	sort.Strings(eventSigns)
+	decodedEvents := b.GetDecodedEventSigns(bytecodeParser)
+	for _, sign := range eventSigns {
+		entry := newEventEntry(sign, decodedEvents[sign])
+		// only the number of indexed inputs is known from the LOG instruction, they are assumed to be the leading ones
+		if indexed, ok := events.IndexedTopics[sign]; ok && indexed <= len(entry.Inputs) {
+			for i := 0; i < indexed; i++ {
+				entry.Inputs[i].Indexed = true
+				entry.Inputs[i].Inferred = true
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	errorSigns := b.GetErrorSigns(bytecodeParser).List()
+	sort.Strings(errorSigns)
+	decodedErrors := b.GetDecodedErrorSigns(bytecodeParser)
+	for _, sign := range errorSigns {
+		entries = append(entries, newErrorEntry(sign, decodedErrors[sign]))
+	}
+	return entries
 }
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/arhamj/abi-extractor/pkg/asm"
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"github.com/arhamj/abi-extractor/pkg/util"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func init() {
+	logger, _ := zap.NewDevelopment()
+	zap.ReplaceGlobals(logger)
+}
+
+type testParser struct {
+	functionSigns []string
+	functions     map[string]asm.Function
+	eventSigns    []string
+	errorSigns    []string
+}
+
+func (p testParser) GetFunctionSigns() asm.FunctionSigns {
+	return asm.NewFunctionSigns(p.functionSigns)
+}
+
+func (p testParser) GetFunctionEntries() map[string]uint64 {
+	return make(map[string]uint64)
+}
+
+func (p testParser) GetErrorSigns() asm.ErrorSigns {
+	return asm.NewErrorSigns(p.errorSigns)
+}
+
+func (p testParser) GetFunctions() map[string]asm.Function {
+	return p.functions
+}
+
+func (p testParser) GetEventSigns() asm.EventSigns {
+	return asm.NewEventSigns(p.eventSigns)
+}
+
+type testSignMapping struct {
+	kind     scraper.MappingKind
+	hexSign  string
+	textSign string
+}
+
+// NewTestScraperDb returns an in memory scraper db seeded with the given mappings
+func NewTestScraperDb(t *testing.T, mappings []testSignMapping) *sql.DB {
+	db, err := util.NewSQLiteDB(":memory:", scraper.FourByteMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection to :memory: opens a new database
+	db.SetMaxOpenConns(1)
+	for i, m := range mappings {
+		_, err = db.Exec("INSERT INTO sign_mapping_fourbyte (kind,hex_sign,string_sign,created_at) VALUES (?,?,?,?)",
+			m.kind, m.hexSign, m.textSign, time.Unix(int64(i), 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func TestBytecodeService_GetABI(t *testing.T) {
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Function, "0xa9059cbb", "transfer(address,uint256)"},
+		{scraper.Function, "0x70a08231", "balanceOf(address)"},
+		{scraper.Function, "0xac9650d8", "multicall(bytes[])"},
+		{scraper.Function, "0x1cff79cd", "execute(address,bytes)"},
+		{scraper.Event, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", "Transfer(address,address,uint256)"},
+		{scraper.Function, "0xcf479181", "InsufficientBalance(uint256,uint256)"},
+	})
+	b := NewBytecodeService(NewSignDecoder(external.NewSamczsunGateway(),
+		WithSourceChain(NewSourceChain(WithSource(NewSQLiteSource(db), 0)))))
+	parser := testParser{
+		functionSigns: []string{"0xa9059cbb", "0x70a08231", "0xac9650d8", "0x1cff79cd"},
+		eventSigns:    []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+		errorSigns:    []string{"0xcf479181"},
+	}
+	got := b.GetABI(parser)
+	parsed, err := abi.JSON(strings.NewReader(got))
+	if err != nil {
+		t.Fatalf("GetABI() returned an ABI which cannot be loaded, err = %v, abi = %s", err, got)
+	}
+	for _, sign := range parser.functionSigns {
+		if _, err := parsed.MethodById(hexutil.MustDecode(sign)); err != nil {
+			t.Errorf("GetABI() method %s not found", sign)
+		}
+	}
+	for _, sign := range parser.eventSigns {
+		if _, err := parsed.EventByID(common.HexToHash(sign)); err != nil {
+			t.Errorf("GetABI() event %s not found", sign)
+		}
+	}
+	if e, ok := parsed.Errors["InsufficientBalance"]; !ok || hexutil.Encode(e.ID[:4]) != "0xcf479181" {
+		t.Errorf("GetABI() error 0xcf479181 not found, abi = %s", got)
+	}
+}
+
+// ropstenTokenBytecode is the runtime of the ERC-20 token 0x43064693d3d38ad6a7cb579e0d6d9718c8aa6b62 on Ropsten,
+// taken from the oog test of the go-ethereum callTracer
+const ropstenTokenBytecode = "6060604052600436106100ba576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff168063" +
+	"06fdde03146100bf578063095ea7b31461014d57806318160ddd146101a757806323b872dd146101d0578063313ce5671461024957806342" +
+	"966c68146102785780635a3b7e42146102b357806370a082311461034157806379cc67901461038e57806395d89b41146103e8578063a905" +
+	"9cbb14610476578063dd62ed3e146104b8575b600080fd5b34156100ca57600080fd5b6100d2610524565b60405180806020018281038252" +
+	"83818151815260200191508051906020019080838360005b838110156101125780820151818401526020810190506100f7565b5050505090" +
+	"5090810190601f16801561013f5780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b3415" +
+	"61015857600080fd5b61018d600480803573ffffffffffffffffffffffffffffffffffffffff169060200190919080359060200190919050" +
+	"5061055d565b604051808215151515815260200191505060405180910390f35b34156101b257600080fd5b6101ba6105ea565b6040518082" +
+	"815260200191505060405180910390f35b34156101db57600080fd5b61022f600480803573ffffffffffffffffffffffffffffffffffffff" +
+	"ff1690602001909190803573ffffffffffffffffffffffffffffffffffffffff169060200190919080359060200190919050506105f0565b" +
+	"604051808215151515815260200191505060405180910390f35b341561025457600080fd5b61025c610910565b604051808260ff1660ff16" +
+	"815260200191505060405180910390f35b341561028357600080fd5b6102996004808035906020019091905050610915565b604051808215" +
+	"151515815260200191505060405180910390f35b34156102be57600080fd5b6102c6610a18565b6040518080602001828103825283818151" +
+	"815260200191508051906020019080838360005b838110156103065780820151818401526020810190506102eb565b505050509050908101" +
+	"90601f1680156103335780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b341561034c57" +
+	"600080fd5b610378600480803573ffffffffffffffffffffffffffffffffffffffff16906020019091905050610a51565b60405180828152" +
+	"60200191505060405180910390f35b341561039957600080fd5b6103ce600480803573ffffffffffffffffffffffffffffffffffffffff16" +
+	"906020019091908035906020019091905050610a69565b604051808215151515815260200191505060405180910390f35b34156103f35760" +
+	"0080fd5b6103fb610bf8565b6040518080602001828103825283818151815260200191508051906020019080838360005b8381101561043b" +
+	"578082015181840152602081019050610420565b50505050905090810190601f1680156104685780820380516001836020036101000a0319" +
+	"16815260200191505b509250505060405180910390f35b341561048157600080fd5b6104b6600480803573ffffffffffffffffffffffffff" +
+	"ffffffffffffff16906020019091908035906020019091905050610c31565b005b34156104c357600080fd5b61050e600480803573ffffff" +
+	"ffffffffffffffffffffffffffffffffff1690602001909190803573ffffffffffffffffffffffffffffffffffffffff1690602001909190" +
+	"5050610e34565b6040518082815260200191505060405180910390f35b6040805190810160405280600881526020017f446f70616d696e65" +
+	"00000000000000000000000000000000000000000000000081525081565b600081600260003373ffffffffffffffffffffffffffffffffff" +
+	"ffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008573ffffffffffffffffffffffff" +
+	"ffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000208190555060019050929150" +
+	"50565b60005481565b6000808373ffffffffffffffffffffffffffffffffffffffff161415151561061757600080fd5b81600160008673ff" +
+	"ffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020" +
+	"541015151561066557600080fd5b600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffff" +
+	"ffffffffffff1681526020019081526020016000205482600160008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffff" +
+	"ffffffffffffffffffffffffffffff1681526020019081526020016000205401101515156106f157fe5b600260008573ffffffffffffffff" +
+	"ffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060003373ffffff" +
+	"ffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205482" +
+	"1115151561077c57600080fd5b81600160008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffff" +
+	"ffffffffffff1681526020019081526020016000206000828254039250508190555081600160008573ffffffffffffffffffffffffffffff" +
+	"ffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254019250508190555081" +
+	"600260008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081" +
+	"5260200160002060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152" +
+	"602001908152602001600020600082825403925050819055508273ffffffffffffffffffffffffffffffffffffffff168473ffffffffffff" +
+	"ffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef8460405180828152" +
+	"60200191505060405180910390a3600190509392505050565b601281565b600081600160003373ffffffffffffffffffffffffffffffffff" +
+	"ffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020541015151561096557600080fd5b8160" +
+	"0160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152" +
+	"602001600020600082825403925050819055508160008082825403925050819055503373ffffffffffffffffffffffffffffffffffffffff" +
+	"167fcc16f5dbb4873280815c1ee09dbd06736cffcc184412cf7a71a0fdb75d397ca5836040518082815260200191505060405180910390a2" +
+	"60019050919050565b6040805190810160405280600981526020017f446f706d6e20302e3200000000000000000000000000000000000000" +
+	"0000000081525081565b60016020528060005260406000206000915090505481565b600081600160008573ffffffffffffffffffffffffff" +
+	"ffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205410151515610ab957600080" +
+	"fd5b600260008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001" +
+	"90815260200160002060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16" +
+	"8152602001908152602001600020548211151515610b4457600080fd5b81600160008573ffffffffffffffffffffffffffffffffffffffff" +
+	"1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282540392505081905550816000808282" +
+	"5403925050819055508273ffffffffffffffffffffffffffffffffffffffff167fcc16f5dbb4873280815c1ee09dbd06736cffcc184412cf" +
+	"7a71a0fdb75d397ca5836040518082815260200191505060405180910390a26001905092915050565b604080519081016040528060058152" +
+	"6020017f444f504d4e00000000000000000000000000000000000000000000000000000081525081565b60008273ffffffffffffffffffff" +
+	"ffffffffffffffffffff1614151515610c5757600080fd5b80600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffff" +
+	"ffffffffffffffffffffffffffffffffff1681526020019081526020016000205410151515610ca557600080fd5b600160008373ffffffff" +
+	"ffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020548160" +
+	"0160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152" +
+	"602001600020540110151515610d3157fe5b80600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffff" +
+	"ffffffffffffffffffffff1681526020019081526020016000206000828254039250508190555080600160008473ffffffffffffffffffff" +
+	"ffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825401925050" +
+	"819055508173ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff167fddf252ad1b" +
+	"e2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040518082815260200191505060405180910390a35050565b600260" +
+	"20528160005260406000206020528060005260406000206000915091505054815600a165627a7a723058206d93424f4e7b11929b8276a269" +
+	"038402c10c0ddf21800e999916ddd9dff4a7630029"
+
+func TestBytecodeService_GetABI_IndexedEvents(t *testing.T) {
+	transfer := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Event, transfer, "Transfer(address,address,uint256)"},
+	})
+	b := NewBytecodeService(NewSignDecoder(external.NewSamczsunGateway(),
+		WithSourceChain(NewSourceChain(WithSource(NewSQLiteSource(db), 0)))))
+	d, err := asm.NewDisassembler(hexutil.MustDecode("0x" + ropstenTokenBytecode))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser, _ := asm.NewBytecodeParser(d, asm.AutoDetect)
+	got := b.GetABI(parser)
+	parsed, err := abi.JSON(strings.NewReader(got))
+	if err != nil {
+		t.Fatalf("GetABI() returned an ABI which cannot be loaded, err = %v, abi = %s", err, got)
+	}
+
+	// log emitted by the token for the transfer of the oog test when replayed with enough gas
+	topics := []common.Hash{
+		common.HexToHash(transfer),
+		common.HexToHash("0x00000000000000000000000094194bc2aaf494501d7880b61274a169f6502a54"),
+		common.HexToHash("0x000000000000000000000000e77b1ac803616503510bed0086e3a7be2627a699"),
+	}
+	data := hexutil.MustDecode("0x00000000000000000000000000000000000000000000000000000009502f9000")
+	event, err := parsed.EventByID(topics[0])
+	if err != nil {
+		t.Fatalf("GetABI() event %s not found, abi = %s", transfer, got)
+	}
+	for i, input := range event.Inputs {
+		if input.Indexed != (i < 2) {
+			t.Errorf("GetABI() Transfer input %d indexed = %v, abi = %s", i, input.Indexed, got)
+		}
+	}
+	indexed := make(abi.Arguments, 0)
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(topics)-1 {
+		t.Fatalf("GetABI() Transfer has %d indexed inputs for %d topics", len(indexed), len(topics)-1)
+	}
+	values, err := event.Inputs.NonIndexed().Unpack(data)
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	if amount, ok := values[0].(*big.Int); !ok || amount.Cmp(big.NewInt(40_000_000_000)) != 0 {
+		t.Errorf("Unpack() amount = %v, want 40000000000", values[0])
+	}
+	for _, entry := range b.GetABIEntries(parser) {
+		if entry.Name == "Transfer" && !entry.Inputs[0].Inferred {
+			t.Errorf("GetABIEntries() indexed inputs not marked as inferred, got = %+v", entry)
+		}
+	}
+}
+
+func TestBytecodeService_InferFunctionSigns(t *testing.T) {
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Function, "0xa9059cbb", "transfer(address,uint256)"},
+	})
+	b := NewBytecodeService(NewSignDecoder(external.NewSamczsunGateway(),
+		WithSourceChain(NewSourceChain(WithSource(NewSQLiteSource(db), 0)))))
+	parser := testParser{
+		functionSigns: []string{"0xa9059cbb", "0x0badf00d", "0x0000dead"},
+		functions: map[string]asm.Function{
+			"0xa9059cbb": {Arguments: []string{"address", "uint256"}},
+			"0x0badf00d": {Arguments: []string{"address", "bool", "bytes"}, Outputs: []string{"string"},
+				StateMutability: asm.Payable},
+			"0x0000dead": {Arguments: []string{}},
+		},
+	}
+	want := map[string]string{
+		"0x0badf00d": "unknown_0badf00d(address,bool,bytes)",
+		"0x0000dead": "unknown_0000dead()",
+	}
+	decoded := b.GetDecodedFunctionSigns(parser)
+	if got := b.InferFunctionSigns(parser.functionSigns, parser.functions, decoded); !reflect.DeepEqual(got, want) {
+		t.Errorf("InferFunctionSigns() got = %v, want %v", got, want)
+	}
+	for _, entry := range b.GetABIEntries(parser) {
+		if entry.Name == "unknown_0badf00d" && (len(entry.Inputs) != 3 || entry.Inputs[2].Type != "bytes" ||
+			!entry.Inputs[2].Inferred || len(entry.Outputs) != 1 || entry.Outputs[0].Type != "string" ||
+			!entry.Outputs[0].Inferred || entry.StateMutability != "payable") {
+			t.Errorf("GetABIEntries() inferred inputs, outputs and state mutability not used, got = %+v", entry)
+		}
+		if entry.Name == "transfer" && entry.Inputs[0].Inferred {
+			t.Errorf("GetABIEntries() decoded inputs marked as inferred, got = %+v", entry)
+		}
+	}
+}
+
+func TestABIEntry_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry ABIEntry
+		want  string
+	}{
+		{
+			name:  "Unknown outputs are left out",
+			entry: ABIEntry{Type: ABIFunction, Name: "balanceOf", Inputs: []ABIArgument{}},
+			want:  `{"type":"function","name":"balanceOf","inputs":[]}`,
+		},
+		{
+			name:  "Function returning nothing",
+			entry: ABIEntry{Type: ABIFunction, Name: "renounceOwnership", Inputs: []ABIArgument{}, Outputs: []ABIArgument{}},
+			want:  `{"type":"function","name":"renounceOwnership","inputs":[],"outputs":[]}`,
+		},
+		{
+			name: "Inferred outputs",
+			entry: ABIEntry{Type: ABIFunction, Name: "totalSupply", Inputs: []ABIArgument{},
+				Outputs: newInferredArguments([]string{"uint256"})},
+			want: `{"type":"function","name":"totalSupply","inputs":[],"outputs":[{"name":"","type":"uint256","inferred":true}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.entry)
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTextSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		textSign  string
+		wantName  string
+		wantTypes []string
+		wantErr   bool
+	}{
+		{
+			name:      "Elementary types",
+			textSign:  "transferFrom(address,address,uint256)",
+			wantName:  "transferFrom",
+			wantTypes: []string{"address", "address", "uint256"},
+		},
+		{
+			name:      "No arguments",
+			textSign:  "totalSupply()",
+			wantName:  "totalSupply",
+			wantTypes: []string{},
+		},
+		{
+			name:      "Tuple and arrays",
+			textSign:  "swap((address,uint256)[],bytes32[2],bytes)",
+			wantName:  "swap",
+			wantTypes: []string{"tuple[]", "bytes32[2]", "bytes"},
+		},
+		{
+			name:     "Invalid elementary type",
+			textSign: "foo(addres,uint256)",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, inputs, err := ParseTextSignature(tt.textSign)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTextSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if name != tt.wantName {
+				t.Errorf("ParseTextSignature() name = %v, want %v", name, tt.wantName)
+			}
+			if len(inputs) != len(tt.wantTypes) {
+				t.Fatalf("ParseTextSignature() inputs = %v, want %v", inputs, tt.wantTypes)
+			}
+			for i, typ := range tt.wantTypes {
+				if inputs[i].Type != typ {
+					t.Errorf("ParseTextSignature() input %d type = %v, want %v", i, inputs[i].Type, typ)
+				}
+			}
+		})
+	}
+}
+
+func TestBytecodeService_newFunctionEntry_Placeholder(t *testing.T) {
+	got := newFunctionEntry("0xdeadbeef", "")
+	if got.Name != "unknown_deadbeef" || got.Type != ABIFunction || len(got.Inputs) != 0 {
+		t.Errorf("newFunctionEntry() got = %+v", got)
+	}
+	got = newEventEntry("0x1234", "not a signature")
+	if got.Name != "unknown_1234" || got.Type != ABIEvent || got.Anonymous == nil {
+		t.Errorf("newEventEntry() got = %+v", got)
+	}
+	got = newErrorEntry("0x82b42900", "")
+	if got.Name != "unknown_82b42900" || got.Type != ABIError || len(got.Inputs) != 0 {
+		t.Errorf("newErrorEntry() got = %+v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+	"reflect"
+)
+
+// DecodedCall is calldata decoded with the first text signature of its selector which fits the data
+type DecodedCall struct {
+	// Selector is the first 4 bytes of the calldata prefixed with 0x
+	Selector  string         `json:"selector"`
+	Signature TextSignature  `json:"signature"`
+	Name      string         `json:"name"`
+	Arguments []DecodedValue `json:"arguments"`
+}
+
+// DecodedValue is a single argument, Value is the go-ethereum representation of the type, for e.g. *big.Int for
+// uint256 and common.Address for address
+type DecodedValue struct {
+	Name  string
+	Type  string
+	Value interface{}
+	// Indexed is set for event parameters read from the topics, indexed dynamic values are only known by their hash
+	Indexed bool
+}
+
+// MarshalJSON writes the value formatted with String, big numbers would otherwise lose precision in most JSON readers
+func (v DecodedValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name    string `json:"name"`
+		Type    string `json:"type"`
+		Value   string `json:"value"`
+		Indexed bool   `json:"indexed,omitempty"`
+	}{Name: v.Name, Type: v.Type, Value: v.String(), Indexed: v.Indexed})
+}
+
+// String formats bytes as hex and every other value with its default format
+func (v DecodedValue) String() string {
+	if b, ok := v.Value.([]byte); ok {
+		return hexutil.Encode(b)
+	}
+	rv := reflect.ValueOf(v.Value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprint(v.Value)
+}
+
+// DecodeCalldata resolves the selector of the calldata through every source of the chain, and decodes the arguments
+// with every text signature found in the order of the sources. The first signature which decodes the data without any
+// leftover or non-canonical bytes is returned, see unpackStrict
+func (s SignDecoderService) DecodeCalldata(data []byte) (*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, errors.New("calldata is shorter than a function selector")
+	}
+	selector := hexutil.Encode(data[:4])
+	candidates, err := s.lookupAll(FunctionSignature, selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		call, err := decodeCall(candidate, data)
+		if err != nil {
+			s.logger.Debug("DecodeCalldata: candidate rejected", zap.String("sign", selector),
+				zap.String("textSign", candidate.Sign), zap.Error(err))
+			continue
+		}
+		return call, nil
+	}
+	return nil, fmt.Errorf("no text signature found which decodes the calldata of %s", selector)
+}
+
+// decodeCall decodes the calldata with a single text signature
+func decodeCall(textSign TextSignature, data []byte) (*DecodedCall, error) {
+	name, inputs, err := ParseTextSignature(textSign.Sign)
+	if err != nil {
+		return nil, err
+	}
+	args, err := ToArguments(inputs)
+	if err != nil {
+		return nil, err
+	}
+	method := abi.NewMethod(name, name, abi.Function, "", false, false, args, nil)
+	if !bytes.Equal(method.ID, data[:4]) {
+		return nil, fmt.Errorf("selector of %s is %s", method.Sig, hexutil.Encode(method.ID))
+	}
+	values, err := unpackStrict(args, data[4:])
+	if err != nil {
+		return nil, err
+	}
+	return &DecodedCall{
+		Selector:  hexutil.Encode(data[:4]),
+		Signature: textSign,
+		Name:      name,
+		Arguments: newDecodedValues(args, values),
+	}, nil
+}
+
+func newDecodedValues(args abi.Arguments, values []interface{}) []DecodedValue {
+	res := make([]DecodedValue, 0, len(values))
+	for i, value := range values {
+		res = append(res, DecodedValue{Name: args[i].Name, Type: args[i].Type.String(), Value: value})
+	}
+	return res
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSignDecoderService_DecodeCalldata(t *testing.T) {
+	// many_msg_babbage(bytes1) collides with transfer(address,uint256) and is the oldest in the db
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Function, "0xa9059cbb", "many_msg_babbage(bytes1)"},
+		{scraper.Function, "0xa9059cbb", "transfer(address,uint256)"},
+		{scraper.Function, "0x70a08231", "balanceOf(address)"},
+	})
+	s := NewSignDecoder(external.NewSamczsunGateway(),
+		WithSourceChain(NewSourceChain(WithSource(NewSQLiteSource(db), 0))))
+	tests := []struct {
+		name     string
+		calldata string
+		wantSign string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "Collision resolved by decoding",
+			calldata: "0xa9059cbb" + "0000000000000000000000001111111111111111111111111111111111111111" +
+				"00000000000000000000000000000000000000000000000000000000000003e8",
+			wantSign: "transfer(address,uint256)",
+			want:     []string{"address 0x1111111111111111111111111111111111111111", "uint256 1000"},
+		},
+		{
+			name:     "Only the colliding signature decodes",
+			calldata: "0xa9059cbb" + "0100000000000000000000000000000000000000000000000000000000000000",
+			wantSign: "many_msg_babbage(bytes1)",
+			want:     []string{"bytes1 0x01"},
+		},
+		{
+			name: "Dirty address padding",
+			calldata: "0x70a08231" + "ff00000000000000000000001111111111111111111111111111111111111111" +
+				"00000000000000000000000000000000000000000000000000000000000003e8",
+			wantErr: true,
+		},
+		{
+			name:     "Shorter than a selector",
+			calldata: "0xa905",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.DecodeCalldata(hexutil.MustDecode(tt.calldata))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeCalldata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Signature.Sign != tt.wantSign {
+				t.Errorf("DecodeCalldata() sign = %v, want %v", got.Signature.Sign, tt.wantSign)
+			}
+			values := make([]string, 0, len(got.Arguments))
+			for _, arg := range got.Arguments {
+				values = append(values, arg.Type+" "+strings.ToLower(arg.String()))
+			}
+			if !reflect.DeepEqual(values, tt.want) {
+				t.Errorf("DecodeCalldata() arguments = %v, want %v", values, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sort"
+	"time"
+)
+
+const (
+	// decodedScore rewards a text signature which hashes to the hex signature it was returned for
+	decodedScore = 2
+	// sourceScore is added for every source reporting the text signature
+	sourceScore = 1
+	// oldestScore rewards the text signature submitted first, colliding ones are usually submitted later on purpose
+	oldestScore = 1
+	// verifiedScore rewards a text signature which decodes every sample given, see ResolveWithCalldata
+	verifiedScore = 2
+	// spamScore is taken off a text signature flagged as spam by every source reporting it
+	spamScore = 2
+)
+
+// Candidate is a text signature returned for a hex signature, along with what is known about it across the sources
+type Candidate struct {
+	Sign string `json:"sign"`
+	// Sources are the names of the sources which returned the text signature, in the order of the chain
+	Sources []string `json:"sources"`
+	// CreatedAt is the oldest creation time reported by the sources, zero when none of them tells
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// Spam is set when every source reporting the text signature flags it as likely spam
+	Spam bool `json:"spam"`
+	// Decoded is set when the text signature parses and hashes to the hex signature
+	Decoded bool `json:"decoded"`
+	// Verified is set when the text signature decodes every sample given, see ResolveWithCalldata and ResolveWithLogs
+	Verified bool `json:"verified"`
+	// Score ranks the candidates of a hex signature, the higher the more likely
+	Score int `json:"score"`
+}
+
+// ResolveAll returns every text signature found for the hex signature by the sources of the chain, best ranked first.
+// The score favours the text signatures which hash to the hex signature, are reported by more sources, are the oldest
+// and are not flagged as spam
+func (s SignDecoderService) ResolveAll(kind SignatureKind, hexSign string) ([]Candidate, error) {
+	results, err := s.sources.lookupSources(context.Background(), kind, hexSign, MergeAll)
+	if err != nil {
+		return nil, err
+	}
+	return rankCandidates(kind, hexSign, results), nil
+}
+
+// rankCandidates merges the text signatures returned by each source and scores them
+func rankCandidates(kind SignatureKind, hexSign string, results [][]TextSignature) []Candidate {
+	res := make([]Candidate, 0)
+	index := make(map[string]int)
+	for _, textSigns := range results {
+		for _, textSign := range textSigns {
+			i, ok := index[textSign.Sign]
+			if !ok {
+				i = len(res)
+				index[textSign.Sign] = i
+				res = append(res, Candidate{
+					Sign:    textSign.Sign,
+					Spam:    true,
+					Decoded: signatureMatches(kind, textSign.Sign, hexSign),
+				})
+			}
+			c := &res[i]
+			if len(c.Sources) == 0 || c.Sources[len(c.Sources)-1] != textSign.Source {
+				c.Sources = append(c.Sources, textSign.Source)
+			}
+			if textSign.Verified {
+				c.Spam = false
+			}
+			if !textSign.CreatedAt.IsZero() && (c.CreatedAt.IsZero() || textSign.CreatedAt.Before(c.CreatedAt)) {
+				c.CreatedAt = textSign.CreatedAt
+			}
+		}
+	}
+
+	oldest := -1
+	for i, c := range res {
+		if !c.CreatedAt.IsZero() && (oldest < 0 || c.CreatedAt.Before(res[oldest].CreatedAt)) {
+			oldest = i
+		}
+	}
+	for i := range res {
+		c := &res[i]
+		if c.Decoded {
+			c.Score += decodedScore
+		}
+		c.Score += sourceScore * len(c.Sources)
+		if i == oldest {
+			c.Score += oldestScore
+		}
+		if c.Spam {
+			c.Score -= spamScore
+		}
+	}
+	sortCandidates(res)
+	return res
+}
+
+// sortCandidates orders the candidates by score, then by creation time with the unknown ones last
+func sortCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].CreatedAt.IsZero() != candidates[j].CreatedAt.IsZero() {
+			return !candidates[i].CreatedAt.IsZero()
+		}
+		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+	})
+}
+
+// signatureMatches tells whether the text signature hashes to the hex signature, the selector for functions and errors
+// and the topic for events
+func signatureMatches(kind SignatureKind, textSign string, hexSign string) bool {
+	name, inputs, err := ParseTextSignature(textSign)
+	if err != nil {
+		return false
+	}
+	args, err := ToArguments(inputs)
+	if err != nil {
+		return false
+	}
+	want, err := hexutil.Decode(hexSign)
+	if err != nil {
+		return false
+	}
+	if kind == EventSignature {
+		event := abi.NewEvent(name, name, false, args)
+		return bytes.Equal(event.ID.Bytes(), want)
+	}
+	method := abi.NewMethod(name, name, abi.Function, "", false, false, args, nil)
+	return bytes.Equal(method.ID, want)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRankCandidates(t *testing.T) {
+	transfer := "transfer(address,uint256)"
+	babbage := "many_msg_babbage(bytes1)"
+	tests := []struct {
+		name    string
+		kind    SignatureKind
+		hexSign string
+		results [][]TextSignature
+		want    []Candidate
+	}{
+		{
+			name:    "Colliding selector, oldest and most sourced first",
+			kind:    FunctionSignature,
+			hexSign: "0xa9059cbb",
+			results: [][]TextSignature{
+				{
+					{Sign: babbage, Verified: true, Source: "sqlite", CreatedAt: time.Unix(200, 0).UTC()},
+					{Sign: transfer, Verified: true, Source: "sqlite", CreatedAt: time.Unix(100, 0).UTC()},
+				},
+				{
+					{Sign: transfer, Verified: true, Source: "samczsun"},
+					{Sign: babbage, Verified: false, Source: "samczsun"},
+				},
+			},
+			want: []Candidate{
+				{Sign: transfer, Sources: []string{"sqlite", "samczsun"}, CreatedAt: time.Unix(100, 0).UTC(),
+					Decoded: true, Score: 5},
+				{Sign: babbage, Sources: []string{"sqlite", "samczsun"}, CreatedAt: time.Unix(200, 0).UTC(),
+					Decoded: true, Score: 4},
+			},
+		},
+		{
+			name:    "Spam and text signatures which do not hash to the selector",
+			kind:    FunctionSignature,
+			hexSign: "0xa9059cbb",
+			results: [][]TextSignature{
+				{
+					{Sign: "transfer(address)", Verified: true, Source: "samczsun"},
+					{Sign: babbage, Verified: false, Source: "samczsun"},
+					{Sign: transfer, Verified: true, Source: "samczsun"},
+				},
+			},
+			want: []Candidate{
+				{Sign: transfer, Sources: []string{"samczsun"}, Decoded: true, Score: 3},
+				{Sign: "transfer(address)", Sources: []string{"samczsun"}, Score: 1},
+				{Sign: babbage, Sources: []string{"samczsun"}, Spam: true, Decoded: true, Score: 1},
+			},
+		},
+		{
+			name:    "Event topic",
+			kind:    EventSignature,
+			hexSign: "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			results: [][]TextSignature{
+				{{Sign: "Transfer(address,address,uint256)", Verified: true, Source: "sqlite"}},
+			},
+			want: []Candidate{
+				{Sign: "Transfer(address,address,uint256)", Sources: []string{"sqlite"}, Decoded: true, Score: 3},
+			},
+		},
+		{
+			name:    "Not found",
+			kind:    FunctionSignature,
+			hexSign: "0x0badf00d",
+			results: [][]TextSignature{{}, {}},
+			want:    []Candidate{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankCandidates(tt.kind, tt.hexSign, tt.results)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankCandidates() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignDecoderService_ResolveAll(t *testing.T) {
+	s := NewSignDecoder(external.NewSamczsunGateway(), WithSourceChain(NewSourceChain(
+		WithSource(testSource{name: "a", candidates: []string{"many_msg_babbage(bytes1)"}}, 0),
+		WithSource(testSource{name: "b", candidates: []string{"transfer(address,uint256)", "many_msg_babbage(bytes1)"}}, 0),
+	)))
+	got, err := s.ResolveAll(FunctionSignature, "0xa9059cbb")
+	if err != nil {
+		t.Fatalf("ResolveAll() error = %v", err)
+	}
+	want := []Candidate{
+		{Sign: "many_msg_babbage(bytes1)", Sources: []string{"a", "b"}, Decoded: true, Score: 4},
+		{Sign: "transfer(address,uint256)", Sources: []string{"b"}, Decoded: true, Score: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveAll() = %+v, want %+v", got, want)
+	}
+}
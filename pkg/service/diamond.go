@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"github.com/arhamj/abi-extractor/pkg/asm"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sort"
+)
+
+// FacetsSelector is facets() of the EIP-2535 DiamondLoupe, returning (address facetAddress, bytes4[] functionSelectors)[]
+const FacetsSelector = "0x7a0ed627"
+
+var facetsOutputs = mustFacetsOutputs()
+
+// Facet is a contract the diamond delegates the calls of Selectors to
+type Facet struct {
+	Address string
+	// Selectors prefixed with 0x as reported by facets()
+	Selectors []string
+}
+
+// FacetReport is the result of parsing the bytecode of a facet, merged with the selectors routed to it
+type FacetReport struct {
+	Facet
+	// DecodedSigns maps the selectors routed to the facet to their text signature when it could be decoded
+	DecodedSigns map[string]string
+	// NotFound are the routed selectors which were not found in the bytecode of the facet
+	NotFound []string
+	// NotRouted are the selectors found in the bytecode of the facet which the diamond does not route to it
+	NotRouted []string
+}
+
+func mustFacetsOutputs() abi.Arguments {
+	typ, err := abi.NewType("tuple[]", "", []abi.ArgumentMarshaling{
+		{Name: "facetAddress", Type: "address"},
+		{Name: "functionSelectors", Type: "bytes4[]"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{{Type: typ}}
+}
+
+// DecodeFacets decodes the hex result of the facets() call
+func DecodeFacets(result string) ([]Facet, error) {
+	data, err := hexutil.Decode(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("facets() returned no data, the diamond does not implement DiamondLoupe")
+	}
+	values, err := facetsOutputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	decoded := *abi.ConvertType(values[0], new([]struct {
+		FacetAddress      common.Address
+		FunctionSelectors [][4]byte
+	})).(*[]struct {
+		FacetAddress      common.Address
+		FunctionSelectors [][4]byte
+	})
+	res := make([]Facet, 0, len(decoded))
+	for _, f := range decoded {
+		facet := Facet{Address: f.FacetAddress.Hex(), Selectors: make([]string, 0, len(f.FunctionSelectors))}
+		for _, selector := range f.FunctionSelectors {
+			facet.Selectors = append(facet.Selectors, hexutil.Encode(selector[:]))
+		}
+		res = append(res, facet)
+	}
+	return res, nil
+}
+
+// GetFacetReport compares the selectors routed to the facet with the ones found by the parser of its bytecode and
+// decodes the routed selectors
+func (b BytecodeService) GetFacetReport(facet Facet, facetParser asm.BytecodeParser) FacetReport {
+	found := b.GetFunctionSigns(facetParser)
+	routed := make(map[string]bool, len(facet.Selectors))
+	report := FacetReport{
+		Facet:        facet,
+		DecodedSigns: b.decodeFunctionSigns(facet.Selectors),
+		NotFound:     make([]string, 0),
+		NotRouted:    make([]string, 0),
+	}
+	for _, selector := range facet.Selectors {
+		routed[selector] = true
+		if !found.Signatures[selector] {
+			report.NotFound = append(report.NotFound, selector)
+		}
+	}
+	for _, selector := range found.List() {
+		if !routed[selector] {
+			report.NotRouted = append(report.NotRouted, selector)
+		}
+	}
+	sort.Strings(report.NotRouted)
+	return report
+}
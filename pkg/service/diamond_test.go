@@ -0,0 +1,80 @@
+package service
+
+import (
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeFacets(t *testing.T) {
+	type facet struct {
+		FacetAddress      common.Address
+		FunctionSelectors [][4]byte
+	}
+	packed, err := facetsOutputs.Pack([]facet{
+		{common.HexToAddress("0x1111111111111111111111111111111111111111"), [][4]byte{{0xa9, 0x05, 0x9c, 0xbb}, {0x70, 0xa0, 0x82, 0x31}}},
+		{common.HexToAddress("0x2222222222222222222222222222222222222222"), [][4]byte{{0x7a, 0x0e, 0xd6, 0x27}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		result  string
+		want    []Facet
+		wantErr bool
+	}{
+		{
+			name:   "Two facets",
+			result: hexutil.Encode(packed),
+			want: []Facet{
+				{Address: "0x1111111111111111111111111111111111111111", Selectors: []string{"0xa9059cbb", "0x70a08231"}},
+				{Address: "0x2222222222222222222222222222222222222222", Selectors: []string{"0x7a0ed627"}},
+			},
+		},
+		{
+			name:    "No data",
+			result:  "0x",
+			wantErr: true,
+		},
+		{
+			name:    "Truncated",
+			result:  hexutil.Encode(packed[:40]),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeFacets(tt.result)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeFacets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && !tt.wantErr {
+				t.Errorf("DecodeFacets() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBytecodeService_GetFacetReport(t *testing.T) {
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Function, "0xa9059cbb", "transfer(address,uint256)"},
+		{scraper.Function, "0x70a08231", "balanceOf(address)"},
+	})
+	b := NewBytecodeService(NewSignDecoder(external.NewSamczsunGateway(),
+		WithSourceChain(NewSourceChain(WithSource(NewSQLiteSource(db), 0)))))
+	facet := Facet{Address: "0x1111111111111111111111111111111111111111", Selectors: []string{"0xa9059cbb", "0x70a08231"}}
+	got := b.GetFacetReport(facet, testParser{functionSigns: []string{"0xa9059cbb", "0x18160ddd", "0x095ea7b3"}})
+	want := FacetReport{
+		Facet:        facet,
+		DecodedSigns: map[string]string{"0xa9059cbb": "transfer(address,uint256)", "0x70a08231": "balanceOf(address)"},
+		NotFound:     []string{"0x70a08231"},
+		NotRouted:    []string{"0x095ea7b3", "0x18160ddd"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFacetReport() got = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+	"strings"
+)
+
+// ResolveWithCalldata returns the candidates of the selector, see ResolveAll, without the ones which fail to decode any
+// of the sample calldata. The decoding is strict: the data must have the exact length of the arguments, be padded with
+// zeros and have valid dynamic offsets, see unpackStrict. The candidates which decode every sample are Verified and
+// ranked first. Without samples the candidates are returned unfiltered
+func (s SignDecoderService) ResolveWithCalldata(selector string, samples ...[]byte) ([]Candidate, error) {
+	for _, sample := range samples {
+		if len(sample) < 4 || !strings.EqualFold(hexutil.Encode(sample[:4]), selector) {
+			return nil, fmt.Errorf("sample calldata %s does not start with the selector %s", hexutil.Encode(sample),
+				selector)
+		}
+	}
+	candidates, err := s.ResolveAll(FunctionSignature, selector)
+	if err != nil {
+		return nil, err
+	}
+	return s.filterCandidates(candidates, len(samples), func(textSign TextSignature) error {
+		for _, sample := range samples {
+			if _, err := decodeCall(textSign, sample); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+// ResolveWithLogs returns the candidates of the event topic which decode every sample log, see ResolveWithCalldata.
+// The first topic of the samples must be the event topic
+func (s SignDecoderService) ResolveWithLogs(topic string, samples ...external.EthLog) ([]Candidate, error) {
+	for _, sample := range samples {
+		if len(sample.Topics) == 0 || !strings.EqualFold(sample.Topics[0], topic) {
+			return nil, fmt.Errorf("sample log %v is not emitted by the event %s", sample.Topics, topic)
+		}
+		if _, _, err := parseEthLog(sample); err != nil {
+			return nil, err
+		}
+	}
+	candidates, err := s.ResolveAll(EventSignature, topic)
+	if err != nil {
+		return nil, err
+	}
+	return s.filterCandidates(candidates, len(samples), func(textSign TextSignature) error {
+		for _, sample := range samples {
+			topics, data, _ := parseEthLog(sample)
+			if _, err := decodeLog(textSign, topics, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+// filterCandidates drops the candidates which fail to decode the samples and ranks the others again
+func (s SignDecoderService) filterCandidates(candidates []Candidate, samples int, decode func(TextSignature) error) []Candidate {
+	if samples == 0 {
+		return candidates
+	}
+	res := make([]Candidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if err := decode(TextSignature{Sign: candidate.Sign}); err != nil {
+			s.logger.Debug("filterCandidates: candidate rejected", zap.String("textSign", candidate.Sign),
+				zap.Error(err))
+			continue
+		}
+		candidate.Verified = true
+		candidate.Score += verifiedScore
+		res = append(res, candidate)
+	}
+	sortCandidates(res)
+	return res
+}
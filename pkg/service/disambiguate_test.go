@@ -0,0 +1,127 @@
+package service
+
+import (
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"reflect"
+	"testing"
+)
+
+func TestSignDecoderService_ResolveWithCalldata(t *testing.T) {
+	s := NewSignDecoder(external.NewSamczsunGateway(), WithSourceChain(NewSourceChain(
+		WithSource(NewMemorySource("abi", map[string][]string{
+			"0xa9059cbb": {"many_msg_babbage(bytes1)", "transfer(address,uint256)", "transfer(address)"},
+		}), 0),
+	)))
+	transfer := "0xa9059cbb" + "0000000000000000000000001111111111111111111111111111111111111111" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+	babbage := "0xa9059cbb" + "0100000000000000000000000000000000000000000000000000000000000000"
+	tests := []struct {
+		name    string
+		samples []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Without samples",
+			want: []string{"many_msg_babbage(bytes1)", "transfer(address,uint256)", "transfer(address)"},
+		},
+		{
+			name:    "Wrong length discards the colliding signature",
+			samples: []string{transfer},
+			want:    []string{"transfer(address,uint256)"},
+		},
+		{
+			name:    "Non-canonical padding discards the colliding signature",
+			samples: []string{babbage},
+			want:    []string{"many_msg_babbage(bytes1)"},
+		},
+		{
+			name:    "Every sample has to decode",
+			samples: []string{transfer, babbage},
+			want:    []string{},
+		},
+		{
+			name:    "Sample of another selector",
+			samples: []string{"0x70a08231"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples := make([][]byte, 0, len(tt.samples))
+			for _, sample := range tt.samples {
+				samples = append(samples, hexutil.MustDecode(sample))
+			}
+			got, err := s.ResolveWithCalldata("0xa9059cbb", samples...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveWithCalldata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			signs := make([]string, 0, len(got))
+			for _, candidate := range got {
+				signs = append(signs, candidate.Sign)
+				if candidate.Verified != (len(samples) > 0) {
+					t.Errorf("ResolveWithCalldata() %s verified = %v", candidate.Sign, candidate.Verified)
+				}
+			}
+			if !reflect.DeepEqual(signs, tt.want) {
+				t.Errorf("ResolveWithCalldata() = %v, want %v", signs, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignDecoderService_ResolveWithLogs(t *testing.T) {
+	topic := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	s := NewSignDecoder(external.NewSamczsunGateway(), WithSourceChain(NewSourceChain(
+		WithSource(NewMemorySource("abi", map[string][]string{
+			topic: {"Transfer(address,uint256,uint256)", "Transfer(address,address,uint256)"},
+		}), 0),
+	)))
+	from := "0x0000000000000000000000001111111111111111111111111111111111111111"
+	to := "0x0000000000000000000000002222222222222222222222222222222222222222"
+	amount := "0x00000000000000000000000000000000000000000000000000000000000003e8"
+	tests := []struct {
+		name    string
+		samples []external.EthLog
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Signature which decodes the log",
+			samples: []external.EthLog{{Topics: []string{topic, from, to}, Data: amount}},
+			want:    []string{"Transfer(address,address,uint256)"},
+		},
+		{
+			name:    "Data too short",
+			samples: []external.EthLog{{Topics: []string{topic, from, to}, Data: "0x"}},
+			want:    []string{},
+		},
+		{
+			name:    "Sample of another event",
+			samples: []external.EthLog{{Topics: []string{from}, Data: amount}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ResolveWithLogs(topic, tt.samples...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveWithLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			signs := make([]string, 0, len(got))
+			for _, candidate := range got {
+				signs = append(signs, candidate.Sign)
+			}
+			if !reflect.DeepEqual(signs, tt.want) {
+				t.Errorf("ResolveWithLogs() = %v, want %v", signs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+// maxIndexedParameters is the number of topics available to parameters, the first topic is the event signature
+const maxIndexedParameters = 3
+
+// DecodedLog is a log decoded with the first text signature of its topic which fits the topics and the data
+type DecodedLog struct {
+	// Topic is the event signature, the first topic of the log
+	Topic     string        `json:"topic"`
+	Signature TextSignature `json:"signature"`
+	Name      string        `json:"name"`
+	// Arguments are in the order of the text signature, see DecodedValue.Indexed
+	Arguments []DecodedValue `json:"arguments"`
+}
+
+// DecodeLog resolves the first topic through every source of the chain. Text signatures do not tell which
+// parameters are indexed, every split with as many indexed parameters as there are topics left is tried, the leading
+// parameters being indexed first. The first split which decodes the topics and the data is returned. Anonymous events
+// have no signature topic and cannot be decoded
+func (s SignDecoderService) DecodeLog(topics []common.Hash, data []byte) (*DecodedLog, error) {
+	if len(topics) == 0 {
+		return nil, errors.New("log without topics cannot be decoded")
+	}
+	topic := topics[0].Hex()
+	candidates, err := s.lookupAll(EventSignature, topic)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		log, err := decodeLog(candidate, topics, data)
+		if err != nil {
+			s.logger.Debug("DecodeLog: candidate rejected", zap.String("sign", topic),
+				zap.String("textSign", candidate.Sign), zap.Error(err))
+			continue
+		}
+		return log, nil
+	}
+	return nil, fmt.Errorf("no text signature found which decodes the log of %s", topic)
+}
+
+// DecodeEthLog decodes a log of a receipt, see DecodeLog
+func (s SignDecoderService) DecodeEthLog(log external.EthLog) (*DecodedLog, error) {
+	topics, data, err := parseEthLog(log)
+	if err != nil {
+		return nil, err
+	}
+	return s.DecodeLog(topics, data)
+}
+
+// parseEthLog decodes the hex topics and data of a log
+func parseEthLog(log external.EthLog) ([]common.Hash, []byte, error) {
+	topics := make([]common.Hash, 0, len(log.Topics))
+	for _, topic := range log.Topics {
+		b, err := hexutil.Decode(topic)
+		if err != nil || len(b) != common.HashLength {
+			return nil, nil, fmt.Errorf("invalid topic %s", topic)
+		}
+		topics = append(topics, common.BytesToHash(b))
+	}
+	data, err := hexutil.Decode(log.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid log data: %w", err)
+	}
+	return topics, data, nil
+}
+
+// decodeLog decodes the log with a single text signature, trying every split of indexed parameters
+func decodeLog(textSign TextSignature, topics []common.Hash, data []byte) (*DecodedLog, error) {
+	name, inputs, err := ParseTextSignature(textSign.Sign)
+	if err != nil {
+		return nil, err
+	}
+	args, err := ToArguments(inputs)
+	if err != nil {
+		return nil, err
+	}
+	event := abi.NewEvent(name, name, false, args)
+	if event.ID != topics[0] {
+		return nil, fmt.Errorf("topic of %s is %s", event.Sig, event.ID.Hex())
+	}
+	indexed := len(topics) - 1
+	if indexed > maxIndexedParameters || indexed > len(args) {
+		return nil, fmt.Errorf("%d topics do not fit %s", len(topics), event.Sig)
+	}
+	for _, split := range combinations(len(args), indexed) {
+		values, err := decodeLogSplit(args, split, topics[1:], data)
+		if err == nil {
+			return &DecodedLog{Topic: topics[0].Hex(), Signature: textSign, Name: name, Arguments: values}, nil
+		}
+	}
+	return nil, fmt.Errorf("no split of indexed parameters of %s decodes the log", event.Sig)
+}
+
+// decodeLogSplit decodes the log given the positions of the indexed parameters
+func decodeLogSplit(args abi.Arguments, split []int, topics []common.Hash, data []byte) ([]DecodedValue, error) {
+	isIndexed := make(map[int]bool, len(split))
+	for _, i := range split {
+		isIndexed[i] = true
+	}
+	nonIndexed := make(abi.Arguments, 0, len(args)-len(split))
+	for i, arg := range args {
+		if !isIndexed[i] {
+			nonIndexed = append(nonIndexed, arg)
+		}
+	}
+	values, err := unpackStrict(nonIndexed, data)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]DecodedValue, 0, len(args))
+	for i, arg := range args {
+		value := DecodedValue{Name: arg.Name, Type: arg.Type.String()}
+		if !isIndexed[i] {
+			value.Value, values = values[0], values[1:]
+			res = append(res, value)
+			continue
+		}
+		value.Indexed = true
+		value.Value, topics = topics[0], topics[1:]
+		if !isHashedTopic(arg.Type) {
+			decoded, err := unpackStrict(abi.Arguments{arg}, value.Value.(common.Hash).Bytes())
+			if err != nil {
+				return nil, err
+			}
+			value.Value = decoded[0]
+		}
+		res = append(res, value)
+	}
+	return res, nil
+}
+
+// isHashedTopic reports whether an indexed parameter of the type is stored as the keccak256 hash of its encoding
+//
+//	Ref: https://docs.soliditylang.org/en/latest/abi-spec.html#indexed-event-encoding
+func isHashedTopic(typ abi.Type) bool {
+	switch typ.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return true
+	}
+	return false
+}
+
+// combinations returns every subset of k indices out of n in lexicographic order
+func combinations(n int, k int) [][]int {
+	res := make([][]int, 0)
+	var walk func(start int, current []int)
+	walk = func(start int, current []int) {
+		if len(current) == k {
+			res = append(res, append([]int{}, current...))
+			return
+		}
+		for i := start; i <= n-(k-len(current)); i++ {
+			walk(i+1, append(current, i))
+		}
+	}
+	walk(0, make([]int, 0, k))
+	return res
+}
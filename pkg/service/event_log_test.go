@@ -0,0 +1,110 @@
+package service
+
+import (
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSignDecoderService_DecodeLog(t *testing.T) {
+	transfer := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	deposit := crypto.Keccak256Hash([]byte("Deposit(uint256,address)"))
+	named := crypto.Keccak256Hash([]byte("Named(string,uint256)"))
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Event, transfer.Hex(), "Transfer(address,address,uint256)"},
+		{scraper.Event, deposit.Hex(), "Deposit(uint256,address)"},
+		{scraper.Event, named.Hex(), "Named(string,uint256)"},
+	})
+	s := NewSignDecoder(external.NewSamczsunGateway(),
+		WithSourceChain(NewSourceChain(WithSource(NewSQLiteSource(db), 0))))
+	from := common.HexToHash("0x1111111111111111111111111111111111111111")
+	to := common.HexToHash("0x2222222222222222222222222222222222222222")
+	amount := "0x00000000000000000000000000000000000000000000000000000000000003e8"
+	tests := []struct {
+		name     string
+		topics   []common.Hash
+		data     string
+		wantSign string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "ERC-20 transfer",
+			topics:   []common.Hash{transfer, from, to},
+			data:     amount,
+			wantSign: "Transfer(address,address,uint256)",
+			want: []string{"indexed address 0x1111111111111111111111111111111111111111",
+				"indexed address 0x2222222222222222222222222222222222222222", "uint256 1000"},
+		},
+		{
+			name:     "ERC-721 transfer with every parameter indexed",
+			topics:   []common.Hash{transfer, from, to, common.HexToHash(amount)},
+			data:     "0x",
+			wantSign: "Transfer(address,address,uint256)",
+			want: []string{"indexed address 0x1111111111111111111111111111111111111111",
+				"indexed address 0x2222222222222222222222222222222222222222", "indexed uint256 1000"},
+		},
+		{
+			name:     "Indexed parameter which is not the leading one",
+			topics:   []common.Hash{deposit, common.HexToHash("0xff00000000000000000000000000000000000000000000000000000000000001")},
+			data:     "0x000000000000000000000000" + "1111111111111111111111111111111111111111",
+			wantSign: "Deposit(uint256,address)",
+			want: []string{"indexed uint256 115339776388732929035197660848497720713218148788040405586178452820382218977281",
+				"address 0x1111111111111111111111111111111111111111"},
+		},
+		{
+			name:     "Indexed string is a hash",
+			topics:   []common.Hash{named, crypto.Keccak256Hash([]byte("name"))},
+			data:     amount,
+			wantSign: "Named(string,uint256)",
+			want:     []string{"indexed string " + crypto.Keccak256Hash([]byte("name")).Hex(), "uint256 1000"},
+		},
+		{
+			name:    "More topics than parameters",
+			topics:  []common.Hash{deposit, from, to, from},
+			data:    "0x",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.DecodeLog(tt.topics, hexutil.MustDecode(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeLog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Signature.Sign != tt.wantSign {
+				t.Errorf("DecodeLog() sign = %v, want %v", got.Signature.Sign, tt.wantSign)
+			}
+			values := make([]string, 0, len(got.Arguments))
+			for _, arg := range got.Arguments {
+				value := arg.Type + " " + strings.ToLower(arg.String())
+				if arg.Indexed {
+					value = "indexed " + value
+				}
+				values = append(values, value)
+			}
+			if !reflect.DeepEqual(values, tt.want) {
+				t.Errorf("DecodeLog() arguments = %v, want %v", values, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	got := combinations(4, 2)
+	want := [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("combinations() got = %v, want %v", got, want)
+	}
+	if got := combinations(2, 0); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Errorf("combinations() got = %v, want [[]]", got)
+	}
+}
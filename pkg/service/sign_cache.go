@@ -0,0 +1,227 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheSize = 4096
+	// defaultCacheTTL is how long the candidates of a signature are kept, new colliding signatures are rarely submitted
+	defaultCacheTTL = 30 * 24 * time.Hour
+	// defaultNegativeCacheTTL is how long a signature unknown to the source is kept, so that it is retried once in a while
+	defaultNegativeCacheTTL = 24 * time.Hour
+)
+
+var (
+	// SignCacheMigrations creates the table of the persistent cache of CachedSource in the scraper db
+	SignCacheMigrations = `
+CREATE TABLE IF NOT EXISTS sign_cache
+(
+    source      VARCHAR(32) NOT NULL,
+    kind        VARCHAR(16) NOT NULL,
+    hex_sign    VARCHAR(66) NOT NULL,
+    candidates  TEXT        NOT NULL,
+    expires_at  INTEGER     NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sign_cache__unique_index ON sign_cache (source, kind, hex_sign);
+`
+)
+
+// CachedSource caches the lookups of a source, usually a remote one, in memory and optionally in the sign_cache table
+// of the scraper db. Signatures unknown to the source are cached as well, for a shorter time. Failed lookups are not
+// cached
+type CachedSource struct {
+	logger      *zap.Logger
+	source      SignatureSource
+	db          *sql.DB
+	memory      *lruCache
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+}
+
+type CachedSourceOpt func(cache *CachedSource)
+
+// WithCacheDb persists the cache in the sign_cache table, see SignCacheMigrations
+func WithCacheDb(db *sql.DB) CachedSourceOpt {
+	return func(cache *CachedSource) {
+		cache.db = db
+	}
+}
+
+// WithCacheSize bounds the number of signatures kept in memory, the least recently used are evicted first
+func WithCacheSize(size int) CachedSourceOpt {
+	return func(cache *CachedSource) {
+		cache.size = size
+	}
+}
+
+// WithCacheTTL sets how long the candidates of a signature known by the source are kept
+func WithCacheTTL(ttl time.Duration) CachedSourceOpt {
+	return func(cache *CachedSource) {
+		cache.ttl = ttl
+	}
+}
+
+// WithNegativeCacheTTL sets how long a signature unknown to the source is kept
+func WithNegativeCacheTTL(ttl time.Duration) CachedSourceOpt {
+	return func(cache *CachedSource) {
+		cache.negativeTTL = ttl
+	}
+}
+
+func NewCachedSource(source SignatureSource, opts ...CachedSourceOpt) CachedSource {
+	cache := CachedSource{
+		logger:      zap.L().With(zap.String("loc", "CachedSource")),
+		source:      source,
+		size:        defaultCacheSize,
+		ttl:         defaultCacheTTL,
+		negativeTTL: defaultNegativeCacheTTL,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(&cache)
+	}
+	cache.memory = newLRUCache(cache.size)
+	return cache
+}
+
+// Name is the name of the cached source, the candidates are reported as coming from it
+func (c CachedSource) Name() string {
+	return c.source.Name()
+}
+
+func (c CachedSource) Lookup(ctx context.Context, kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	key := string(kind) + ":" + hexSign
+	now := c.now()
+	if candidates, ok := c.memory.get(key, now); ok {
+		return candidates, nil
+	}
+	if candidates, expiresAt, ok := c.dbGet(kind, hexSign, now); ok {
+		c.memory.put(key, candidates, expiresAt)
+		return candidates, nil
+	}
+	candidates, err := c.source.Lookup(ctx, kind, hexSign)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := now.Add(c.ttl)
+	if len(candidates) == 0 {
+		expiresAt = now.Add(c.negativeTTL)
+	}
+	c.memory.put(key, candidates, expiresAt)
+	c.dbPut(kind, hexSign, candidates, expiresAt)
+	return copyTextSignatures(candidates), nil
+}
+
+// dbGet returns the candidates persisted by a previous run unless they expired
+func (c CachedSource) dbGet(kind SignatureKind, hexSign string, now time.Time) ([]TextSignature, time.Time, bool) {
+	if c.db == nil {
+		return nil, time.Time{}, false
+	}
+	var value string
+	var expiresAt int64
+	err := c.db.QueryRow("SELECT candidates, expires_at FROM sign_cache WHERE source = ? AND kind = ? AND hex_sign = ?",
+		c.source.Name(), string(kind), hexSign).Scan(&value, &expiresAt)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			c.logger.Error("dbGet: error reading sign cache", zap.String("sign", hexSign), zap.Error(err))
+		}
+		return nil, time.Time{}, false
+	}
+	if !now.Before(time.Unix(expiresAt, 0)) {
+		return nil, time.Time{}, false
+	}
+	var candidates []TextSignature
+	if err = json.Unmarshal([]byte(value), &candidates); err != nil {
+		c.logger.Error("dbGet: invalid sign cache entry", zap.String("sign", hexSign), zap.Error(err))
+		return nil, time.Time{}, false
+	}
+	return candidates, time.Unix(expiresAt, 0), true
+}
+
+// dbPut persists the candidates, a failure only costs a remote lookup in the next run
+func (c CachedSource) dbPut(kind SignatureKind, hexSign string, candidates []TextSignature, expiresAt time.Time) {
+	if c.db == nil {
+		return
+	}
+	value, err := json.Marshal(candidates)
+	if err != nil {
+		c.logger.Error("dbPut: error encoding candidates", zap.String("sign", hexSign), zap.Error(err))
+		return
+	}
+	_, err = c.db.Exec("INSERT OR REPLACE INTO sign_cache (source, kind, hex_sign, candidates, expires_at) VALUES (?,?,?,?,?)",
+		c.source.Name(), string(kind), hexSign, string(value), expiresAt.Unix())
+	if err != nil {
+		c.logger.Error("dbPut: error writing sign cache", zap.String("sign", hexSign), zap.Error(err))
+	}
+}
+
+// copyTextSignatures keeps the chain, which sets the source of the candidates returned, from writing into the cache
+func copyTextSignatures(candidates []TextSignature) []TextSignature {
+	return append(make([]TextSignature, 0, len(candidates)), candidates...)
+}
+
+// lruCache is a fixed size map of candidates evicting the least recently used entry, safe for concurrent use
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key        string
+	candidates []TextSignature
+	expiresAt  time.Time
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{size: size, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// get returns a copy of the candidates, an expired entry is removed
+func (l *lruCache) get(key string, now time.Time) ([]TextSignature, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*lruEntry)
+	if !now.Before(entry.expiresAt) {
+		l.order.Remove(e)
+		delete(l.entries, key)
+		return nil, false
+	}
+	l.order.MoveToFront(e)
+	return copyTextSignatures(entry.candidates), true
+}
+
+func (l *lruCache) put(key string, candidates []TextSignature, expiresAt time.Time) {
+	if l.size <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := &lruEntry{key: key, candidates: copyTextSignatures(candidates), expiresAt: expiresAt}
+	if e, ok := l.entries[key]; ok {
+		e.Value = entry
+		l.order.MoveToFront(e)
+		return
+	}
+	l.entries[key] = l.order.PushFront(entry)
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
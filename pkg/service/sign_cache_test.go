@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"github.com/arhamj/abi-extractor/pkg/util"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// countingSource counts the lookups of the wrapped source
+type countingSource struct {
+	SignatureSource
+	lookups *int
+}
+
+func (s countingSource) Lookup(ctx context.Context, kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	*s.lookups++
+	return s.SignatureSource.Lookup(ctx, kind, hexSign)
+}
+
+func TestCachedSource_Lookup(t *testing.T) {
+	db, err := util.NewSQLiteDB(":memory:", scraper.FourByteMigrations, SignCacheMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	lookups := 0
+	remote := countingSource{
+		SignatureSource: NewMemorySource("remote", map[string][]string{"0xa9059cbb": {"transfer(address,uint256)"}}),
+		lookups:         &lookups,
+	}
+	now := time.Unix(1_000_000, 0)
+	newCache := func() CachedSource {
+		cache := NewCachedSource(remote, WithCacheDb(db), WithCacheTTL(time.Hour), WithNegativeCacheTTL(time.Minute))
+		cache.now = func() time.Time { return now }
+		return cache
+	}
+	cache := newCache()
+	want := []TextSignature{{Sign: "transfer(address,uint256)", Verified: true}}
+
+	tests := []struct {
+		name        string
+		cache       CachedSource
+		hexSign     string
+		advance     time.Duration
+		want        []TextSignature
+		wantLookups int
+	}{
+		{name: "Miss", cache: cache, hexSign: "0xa9059cbb", want: want, wantLookups: 1},
+		{name: "Memory hit", cache: cache, hexSign: "0xa9059cbb", want: want, wantLookups: 1},
+		{name: "Db hit of a new process", cache: newCache(), hexSign: "0xa9059cbb", want: want, wantLookups: 1},
+		{name: "Negative miss", cache: cache, hexSign: "0x0badf00d", want: []TextSignature{}, wantLookups: 2},
+		{name: "Negative hit", cache: newCache(), hexSign: "0x0badf00d", want: []TextSignature{}, wantLookups: 2},
+		{name: "Negative expired", cache: cache, hexSign: "0x0badf00d", advance: 2 * time.Minute,
+			want: []TextSignature{}, wantLookups: 3},
+		{name: "Positive not expired", cache: newCache(), hexSign: "0xa9059cbb", want: want, wantLookups: 3},
+		{name: "Positive expired", cache: cache, hexSign: "0xa9059cbb", advance: time.Hour, want: want, wantLookups: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			got, err := tt.cache.Lookup(context.Background(), FunctionSignature, tt.hexSign)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup() got = %+v, want %+v", got, tt.want)
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("Lookup() lookups = %d, want %d", lookups, tt.wantLookups)
+			}
+		})
+	}
+}
+
+func TestCachedSource_LookupError(t *testing.T) {
+	lookups := 0
+	cache := NewCachedSource(countingSource{
+		SignatureSource: testSource{name: "failing", err: errors.New("unavailable")},
+		lookups:         &lookups,
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Lookup(context.Background(), FunctionSignature, "0xa9059cbb"); err == nil {
+			t.Fatalf("Lookup() error = nil")
+		}
+	}
+	if lookups != 2 {
+		t.Errorf("Lookup() cached a failed lookup, lookups = %d", lookups)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	expiresAt := now.Add(time.Hour)
+	l := newLRUCache(2)
+	l.put("a", []TextSignature{{Sign: "a()"}}, expiresAt)
+	l.put("b", []TextSignature{{Sign: "b()"}}, expiresAt)
+	l.get("a", now)
+	l.put("c", []TextSignature{{Sign: "c()"}}, expiresAt)
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := l.get(key, now); ok != want {
+			t.Errorf("get(%s) found = %v, want %v", key, ok, want)
+		}
+	}
+	if _, ok := l.get("a", expiresAt); ok {
+		t.Errorf("get() returned an expired entry")
+	}
+}
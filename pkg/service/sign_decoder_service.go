@@ -1,22 +1,29 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/arhamj/abi-extractor/pkg/external"
-	"github.com/arhamj/abi-extractor/pkg/scraper"
 	"go.uber.org/zap"
+	"time"
 )
 
 type SignDecoderService struct {
 	logger         *zap.Logger
 	scraperDb      *sql.DB
 	decoderGateway external.SamczsunGateway
+	// sources are built from scraperDb and decoderGateway unless given with WithSourceChain
+	sources *SourceChain
 }
 
 type TextSignature struct {
-	Sign     string
-	Verified bool
+	Sign     string `json:"sign"`
+	Verified bool   `json:"verified"`
+	// Source is the name of the signature source which returned the text signature
+	Source string `json:"source,omitempty"`
+	// CreatedAt is when the text signature was submitted to the source, zero when the source does not tell
+	CreatedAt time.Time `json:"createdAt,omitempty"`
 }
 
 type DecoderOpt func(decoder *SignDecoderService)
@@ -27,6 +34,13 @@ func WithScraperDbOpt(scraperDb *sql.DB) DecoderOpt {
 	}
 }
 
+// WithSourceChain replaces the default chain, the scraper db followed by samczsun
+func WithSourceChain(sources SourceChain) DecoderOpt {
+	return func(decoder *SignDecoderService) {
+		decoder.sources = &sources
+	}
+}
+
 func NewSignDecoder(decoderGateway external.SamczsunGateway, opts ...DecoderOpt) SignDecoderService {
 	svc := SignDecoderService{
 		logger:         zap.L().With(zap.String("loc", "SignDecoderService")),
@@ -35,69 +49,41 @@ func NewSignDecoder(decoderGateway external.SamczsunGateway, opts ...DecoderOpt)
 	for _, opt := range opts {
 		opt(&svc)
 	}
+	if svc.sources == nil {
+		chainOpts := make([]SourceChainOpt, 0, 2)
+		if svc.scraperDb != nil {
+			chainOpts = append(chainOpts, WithSource(NewSQLiteSource(svc.scraperDb), 0))
+		}
+		chainOpts = append(chainOpts, WithSource(NewSamczsunSource(decoderGateway), defaultRemoteTimeout))
+		sources := NewSourceChain(chainOpts...)
+		svc.sources = &sources
+	}
 	return svc
 }
 
 func (s SignDecoderService) GetEventTextSignature(eventSign string) (*TextSignature, error) {
-	if s.scraperDb != nil {
-		textSignFromDb, err := s.fetchTextSignatureFromDb(scraper.Event, eventSign)
-		if err == nil {
-			s.logger.Debug("GetEventTextSignature: text sign fetched from db", zap.String("sign", eventSign))
-			return textSignFromDb, nil
-		}
-	}
-	resp, err := s.decoderGateway.GetEventTextSignature(eventSign)
-	if err != nil {
-		return nil, err
-	}
-	result, ok := resp.Result.Event[eventSign]
-	if !ok || len(result) == 0 {
-		s.logger.Debug("GetEventTextSignature: event text signature not found", zap.String("sign", eventSign))
-		return nil, errors.New("text signature not found for event")
-	}
-	return &TextSignature{
-		Sign: result[0].Name,
-		// As per documentation, Filtered field in response is true when the obtained result is likely a spam
-		Verified: !result[0].Filtered,
-	}, nil
+	return s.getTextSignature(EventSignature, eventSign)
 }
 
 func (s SignDecoderService) GetFunctionTextSignature(functionSign string) (*TextSignature, error) {
-	if s.scraperDb != nil {
-		textSignFromDb, err := s.fetchTextSignatureFromDb(scraper.Function, functionSign)
-		if err == nil {
-			s.logger.Debug("GetFunctionTextSignature: text sign fetched from db", zap.String("sign", functionSign))
-			return textSignFromDb, nil
-		}
-	}
-	resp, err := s.decoderGateway.GetFunctionTextSignature(functionSign)
+	return s.getTextSignature(FunctionSignature, functionSign)
+}
+
+// getTextSignature returns the first candidate of the source chain
+func (s SignDecoderService) getTextSignature(kind SignatureKind, hexSign string) (*TextSignature, error) {
+	candidates, err := s.sources.Lookup(context.Background(), kind, hexSign)
 	if err != nil {
 		return nil, err
 	}
-	result, ok := resp.Result.Function[functionSign]
-	if !ok || len(result) == 0 {
-		s.logger.Debug("GetFunctionTextSignature: function text signature not found", zap.String("sign", functionSign))
-		return nil, errors.New("text signature not found for function")
+	if len(candidates) == 0 {
+		s.logger.Debug("getTextSignature: text signature not found", zap.String("kind", string(kind)),
+			zap.String("sign", hexSign))
+		return nil, errors.New("text signature not found for " + string(kind))
 	}
-	return &TextSignature{
-		Sign: result[0].Name,
-		// As per documentation, Filtered field in response is true when the obtained result is likely a spam
-		Verified: !result[0].Filtered,
-	}, nil
+	return &candidates[0], nil
 }
 
-func (s SignDecoderService) fetchTextSignatureFromDb(kind scraper.MappingKind, hexSign string) (*TextSignature, error) {
-	var textSign string
-	row := s.scraperDb.QueryRow("SELECT string_sign FROM sign_mapping_fourbyte WHERE kind = ? AND hex_sign = ? ORDER BY created_at limit 1", kind, hexSign)
-	if row.Err() != nil {
-		return nil, row.Err()
-	}
-	err := row.Scan(&textSign)
-	if err != nil {
-		return nil, err
-	}
-	return &TextSignature{
-		Sign:     textSign,
-		Verified: true,
-	}, nil
+// lookupAll returns the candidates of every source in the chain
+func (s SignDecoderService) lookupAll(kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	return s.sources.LookupAll(context.Background(), kind, hexSign)
 }
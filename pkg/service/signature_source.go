@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+type SignatureKind string
+
+const (
+	FunctionSignature SignatureKind = "function"
+	EventSignature    SignatureKind = "event"
+	// ErrorSignature custom errors share the namespace of the function selectors in every source
+	ErrorSignature SignatureKind = "error"
+)
+
+const (
+	// defaultRemoteTimeout bounds a lookup of the remote sources of the default chain
+	defaultRemoteTimeout = 10 * time.Second
+)
+
+// SignatureSource resolves a hex signature prefixed with 0x into the candidate text signatures
+type SignatureSource interface {
+	// Name identifies the source, for e.g. samczsun, and is set as TextSignature.Source of the candidates
+	Name() string
+	// Lookup returns the candidates in the order of preference of the source, empty when the signature is not known
+	Lookup(ctx context.Context, kind SignatureKind, hexSign string) ([]TextSignature, error)
+}
+
+type MergePolicy int
+
+const (
+	// FirstMatch returns the candidates of the first source which knows the signature
+	FirstMatch MergePolicy = iota
+	// MergeAll queries every source and returns all the candidates in the order of the sources, a text signature
+	// returned by several sources is kept once
+	MergeAll
+)
+
+type chainedSource struct {
+	source  SignatureSource
+	timeout time.Duration
+}
+
+// SourceChain queries signature sources in order, a source which fails or times out is skipped
+type SourceChain struct {
+	logger  *zap.Logger
+	sources []chainedSource
+	policy  MergePolicy
+}
+
+type SourceChainOpt func(chain *SourceChain)
+
+// WithSource appends a source to the chain, a zero timeout does not bound the lookup
+func WithSource(source SignatureSource, timeout time.Duration) SourceChainOpt {
+	return func(chain *SourceChain) {
+		chain.sources = append(chain.sources, chainedSource{source: source, timeout: timeout})
+	}
+}
+
+// WithMergePolicy replaces the merge policy, FirstMatch by default
+func WithMergePolicy(policy MergePolicy) SourceChainOpt {
+	return func(chain *SourceChain) {
+		chain.policy = policy
+	}
+}
+
+func NewSourceChain(opts ...SourceChainOpt) SourceChain {
+	c := SourceChain{
+		logger: zap.L().With(zap.String("loc", "SourceChain")),
+		policy: FirstMatch,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+func (c SourceChain) Name() string {
+	return "chain"
+}
+
+// Lookup queries the sources according to the merge policy. An error is only returned when every source failed
+func (c SourceChain) Lookup(ctx context.Context, kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	results, err := c.lookupSources(ctx, kind, hexSign, c.policy)
+	return distinct(results), err
+}
+
+// LookupAll queries every source regardless of the merge policy, see MergeAll
+func (c SourceChain) LookupAll(ctx context.Context, kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	results, err := c.lookupSources(ctx, kind, hexSign, MergeAll)
+	return distinct(results), err
+}
+
+// lookupSources returns the candidates of each source which answered, in the order of the sources
+func (c SourceChain) lookupSources(ctx context.Context, kind SignatureKind, hexSign string, policy MergePolicy) ([][]TextSignature, error) {
+	res := make([][]TextSignature, 0, len(c.sources))
+	var err error
+	failed := 0
+	for _, s := range c.sources {
+		candidates, lookupErr := c.lookupSource(ctx, s, kind, hexSign)
+		if lookupErr != nil {
+			c.logger.Debug("Lookup: source failed", zap.String("source", s.source.Name()), zap.String("sign", hexSign),
+				zap.Error(lookupErr))
+			err = lookupErr
+			failed++
+			continue
+		}
+		res = append(res, candidates)
+		if policy == FirstMatch && len(candidates) > 0 {
+			break
+		}
+	}
+	if failed > 0 && failed == len(c.sources) {
+		return nil, err
+	}
+	return res, nil
+}
+
+// distinct flattens the candidates of the sources keeping the first of the candidates with the same text signature
+func distinct(results [][]TextSignature) []TextSignature {
+	res := make([]TextSignature, 0)
+	seen := make(map[string]bool)
+	for _, candidates := range results {
+		for _, candidate := range candidates {
+			if !seen[candidate.Sign] {
+				seen[candidate.Sign] = true
+				res = append(res, candidate)
+			}
+		}
+	}
+	return res
+}
+
+func (c SourceChain) lookupSource(ctx context.Context, s chainedSource, kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	candidates, err := s.source.Lookup(ctx, kind, hexSign)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		candidates[i].Source = s.source.Name()
+	}
+	return candidates, nil
+}
+
+// SQLiteSource looks up the signatures scraped from 4byte into the local db, see scraper.FourByteScraper
+type SQLiteSource struct {
+	db *sql.DB
+}
+
+func NewSQLiteSource(db *sql.DB) SQLiteSource {
+	return SQLiteSource{db: db}
+}
+
+func (s SQLiteSource) Name() string {
+	return "sqlite"
+}
+
+// Lookup returns the oldest text signature first
+func (s SQLiteSource) Lookup(ctx context.Context, kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	mappingKind := scraper.Function
+	if kind == EventSignature {
+		mappingKind = scraper.Event
+	}
+	rows, err := s.db.QueryContext(ctx, "SELECT string_sign, created_at FROM sign_mapping_fourbyte WHERE kind = ? AND hex_sign = ? ORDER BY created_at", mappingKind, hexSign)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]TextSignature, 0)
+	for rows.Next() {
+		var textSign, createdAt string
+		if err = rows.Scan(&textSign, &createdAt); err != nil {
+			return nil, err
+		}
+		res = append(res, TextSignature{Sign: textSign, Verified: true, CreatedAt: parseTimestamp(createdAt)})
+	}
+	return res, rows.Err()
+}
+
+// parseTimestamp parses a time as stored by the sqlite driver, zero when the format is unknown
+func parseTimestamp(value string) time.Time {
+	for _, format := range sqlite3.SQLiteTimestampFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// SamczsunSource looks up the Ethereum Signature Database
+type SamczsunSource struct {
+	gateway external.SamczsunGateway
+}
+
+func NewSamczsunSource(gateway external.SamczsunGateway) SamczsunSource {
+	return SamczsunSource{gateway: gateway}
+}
+
+func (s SamczsunSource) Name() string {
+	return "samczsun"
+}
+
+// Lookup returns the candidates flagged as likely spam by samczsun as not verified
+func (s SamczsunSource) Lookup(ctx context.Context, kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	return lookupWithContext(ctx, func() ([]TextSignature, error) {
+		var resp *external.SamczsunResp
+		var err error
+		if kind == EventSignature {
+			resp, err = s.gateway.GetEventTextSignature(hexSign)
+		} else {
+			resp, err = s.gateway.GetFunctionTextSignature(hexSign)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results := resp.Result.Function[hexSign]
+		if kind == EventSignature {
+			results = resp.Result.Event[hexSign]
+		}
+		res := make([]TextSignature, 0, len(results))
+		for _, result := range results {
+			// As per documentation, Filtered field in response is true when the obtained result is likely a spam
+			res = append(res, TextSignature{Sign: result.Name, Verified: !result.Filtered})
+		}
+		return res, nil
+	})
+}
+
+// FourByteSource looks up the 4byte directory without the local copy, see SQLiteSource
+type FourByteSource struct {
+	gateway external.FourByteGateway
+}
+
+func NewFourByteSource(gateway external.FourByteGateway) FourByteSource {
+	return FourByteSource{gateway: gateway}
+}
+
+func (s FourByteSource) Name() string {
+	return "4byte"
+}
+
+// Lookup returns the oldest text signature first
+func (s FourByteSource) Lookup(ctx context.Context, kind SignatureKind, hexSign string) ([]TextSignature, error) {
+	return lookupWithContext(ctx, func() ([]TextSignature, error) {
+		var resp *external.FourByteResp
+		var err error
+		if kind == EventSignature {
+			resp, err = s.gateway.GetEventTextSignature(hexSign)
+		} else {
+			resp, err = s.gateway.GetFunctionTextSignature(hexSign)
+		}
+		if err != nil {
+			return nil, err
+		}
+		res := make([]TextSignature, 0, len(resp.Results))
+		for _, result := range resp.Results {
+			res = append(res, TextSignature{Sign: result.TextSignature, Verified: true, CreatedAt: result.CreatedAt})
+		}
+		sortOldestFirst(res)
+		return res, nil
+	})
+}
+
+// sortOldestFirst orders text signatures by creation time, the gateway sorts by id which is not the submission order
+// of signatures imported from other directories
+func sortOldestFirst(textSigns []TextSignature) {
+	sort.SliceStable(textSigns, func(i, j int) bool {
+		return textSigns[i].CreatedAt.Before(textSigns[j].CreatedAt)
+	})
+}
+
+// MemorySource looks up a fixed map of hex signature to text signatures, for e.g. the signatures of a known ABI
+type MemorySource struct {
+	name  string
+	signs map[string][]string
+}
+
+// NewMemorySource hex signatures of functions and errors are 4 bytes and those of events 32 bytes, they share the map
+func NewMemorySource(name string, signs map[string][]string) MemorySource {
+	return MemorySource{name: name, signs: signs}
+}
+
+func (s MemorySource) Name() string {
+	return s.name
+}
+
+func (s MemorySource) Lookup(_ context.Context, _ SignatureKind, hexSign string) ([]TextSignature, error) {
+	res := make([]TextSignature, 0, len(s.signs[hexSign]))
+	for _, textSign := range s.signs[hexSign] {
+		res = append(res, TextSignature{Sign: textSign, Verified: true})
+	}
+	return res, nil
+}
+
+// lookupWithContext returns when the lookup is done or the context is done, whichever comes first. The gateways do not
+// accept a context, a lookup given up on completes in the background
+func lookupWithContext(ctx context.Context, lookup func() ([]TextSignature, error)) ([]TextSignature, error) {
+	type result struct {
+		candidates []TextSignature
+		err        error
+	}
+	done := make(chan result, 1)
+	go func() {
+		candidates, err := lookup()
+		done <- result{candidates: candidates, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.candidates, r.err
+	}
+}
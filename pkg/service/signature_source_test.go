@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testSource is a signature source which fails with err or waits for delay before returning the candidates
+type testSource struct {
+	name       string
+	candidates []string
+	delay      time.Duration
+	err        error
+}
+
+func (s testSource) Name() string {
+	return s.name
+}
+
+func (s testSource) Lookup(ctx context.Context, _ SignatureKind, _ string) ([]TextSignature, error) {
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return NewMemorySource(s.name, map[string][]string{"": s.candidates}).Lookup(ctx, FunctionSignature, "")
+}
+
+func TestSourceChain_Lookup(t *testing.T) {
+	failing := testSource{name: "failing", err: errors.New("unavailable")}
+	tests := []struct {
+		name    string
+		opts    []SourceChainOpt
+		want    []TextSignature
+		wantErr bool
+	}{
+		{
+			name: "First source which knows the signature",
+			opts: []SourceChainOpt{
+				WithSource(testSource{name: "a"}, 0),
+				WithSource(testSource{name: "b", candidates: []string{"b1()", "b2()"}}, 0),
+				WithSource(testSource{name: "c", candidates: []string{"c1()"}}, 0),
+			},
+			want: []TextSignature{{Sign: "b1()", Verified: true, Source: "b"}, {Sign: "b2()", Verified: true, Source: "b"}},
+		},
+		{
+			name: "Merge every source",
+			opts: []SourceChainOpt{
+				WithSource(testSource{name: "b", candidates: []string{"x()", "y()"}}, 0),
+				WithSource(testSource{name: "c", candidates: []string{"y()", "z()"}}, 0),
+				WithMergePolicy(MergeAll),
+			},
+			want: []TextSignature{{Sign: "x()", Verified: true, Source: "b"}, {Sign: "y()", Verified: true, Source: "b"},
+				{Sign: "z()", Verified: true, Source: "c"}},
+		},
+		{
+			name: "Failing and timed out sources are skipped",
+			opts: []SourceChainOpt{
+				WithSource(failing, 0),
+				WithSource(testSource{name: "slow", candidates: []string{"slow()"}, delay: time.Second}, 10*time.Millisecond),
+				WithSource(testSource{name: "b", candidates: []string{"b1()"}}, 0),
+			},
+			want: []TextSignature{{Sign: "b1()", Verified: true, Source: "b"}},
+		},
+		{
+			name:    "Every source failed",
+			opts:    []SourceChainOpt{WithSource(failing, 0), WithSource(failing, 0)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSourceChain(tt.opts...).Lookup(context.Background(), FunctionSignature, "0xa9059cbb")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteSource_Lookup(t *testing.T) {
+	topic := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Function, "0xa9059cbb", "transfer(address,uint256)"},
+		{scraper.Function, "0xa9059cbb", "many_msg_babbage(bytes1)"},
+		{scraper.Event, topic, "Transfer(address,address,uint256)"},
+	})
+	source := NewSQLiteSource(db)
+	tests := []struct {
+		kind    SignatureKind
+		hexSign string
+		want    []TextSignature
+	}{
+		{
+			kind:    FunctionSignature,
+			hexSign: "0xa9059cbb",
+			want:    []TextSignature{{Sign: "transfer(address,uint256)", Verified: true, CreatedAt: time.Unix(0, 0).UTC()}, {Sign: "many_msg_babbage(bytes1)", Verified: true, CreatedAt: time.Unix(1, 0).UTC()}},
+		},
+		{
+			kind:    ErrorSignature,
+			hexSign: "0xa9059cbb",
+			want:    []TextSignature{{Sign: "transfer(address,uint256)", Verified: true, CreatedAt: time.Unix(0, 0).UTC()}, {Sign: "many_msg_babbage(bytes1)", Verified: true, CreatedAt: time.Unix(1, 0).UTC()}},
+		},
+		{
+			kind:    EventSignature,
+			hexSign: topic,
+			want:    []TextSignature{{Sign: "Transfer(address,address,uint256)", Verified: true, CreatedAt: time.Unix(2, 0).UTC()}},
+		},
+		{
+			kind:    EventSignature,
+			hexSign: "0xa9059cbb",
+			want:    []TextSignature{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind)+" "+tt.hexSign, func(t *testing.T) {
+			got, err := source.Lookup(context.Background(), tt.kind, tt.hexSign)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortOldestFirst(t *testing.T) {
+	textSigns := []TextSignature{
+		{Sign: "many_msg_babbage(bytes1)", CreatedAt: time.Unix(2, 0)},
+		{Sign: "transfer(address,uint256)", CreatedAt: time.Unix(1, 0)},
+		{Sign: "transfer(bytes4[9],bytes5[6],int48[11])", CreatedAt: time.Unix(2, 0)},
+	}
+	sortOldestFirst(textSigns)
+	want := []string{"transfer(address,uint256)", "many_msg_babbage(bytes1)", "transfer(bytes4[9],bytes5[6],int48[11])"}
+	for i, textSign := range textSigns {
+		if textSign.Sign != want[i] {
+			t.Errorf("sortOldestFirst()[%d] = %s, want %s", i, textSign.Sign, want[i])
+		}
+	}
+}
+
+func TestSignDecoderService_WithSourceChain(t *testing.T) {
+	s := NewSignDecoder(external.NewSamczsunGateway(), WithSourceChain(NewSourceChain(
+		WithSource(NewMemorySource("abi", map[string][]string{"0x70a08231": {"balanceOf(address)"}}), 0),
+	)))
+	got, err := s.GetFunctionTextSignature("0x70a08231")
+	if err != nil {
+		t.Fatalf("GetFunctionTextSignature() error = %v", err)
+	}
+	want := &TextSignature{Sign: "balanceOf(address)", Verified: true, Source: "abi"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFunctionTextSignature() got = %+v, want %+v", got, want)
+	}
+	if _, err = s.GetFunctionTextSignature("0xa9059cbb"); err == nil {
+		t.Errorf("GetFunctionTextSignature() found a signature missing from the chain")
+	}
+}
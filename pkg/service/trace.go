@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"github.com/arhamj/abi-extractor/pkg/asm"
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+	"strings"
+)
+
+// DecodedFrame is a call frame of a transaction trace labelled with the called function
+type DecodedFrame struct {
+	Type  string `json:"type"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value,omitempty"`
+	// Function is the text signature of the called function, unknown_<hex> with the argument types inferred from the
+	// code of the callee when it could not be resolved, empty for a plain transfer or a contract creation
+	Function  string         `json:"function,omitempty"`
+	Verified  bool           `json:"verified"`
+	Arguments []DecodedValue `json:"arguments,omitempty"`
+	// Outputs are decoded with the types inferred from the code of the callee, see asm.InferOutputs
+	Outputs []DecodedValue          `json:"outputs,omitempty"`
+	Input   string                  `json:"input"`
+	Output  string                  `json:"output,omitempty"`
+	Error   string                  `json:"error,omitempty"`
+	Logs    []DecodedTransactionLog `json:"logs,omitempty"`
+	Calls   []DecodedFrame          `json:"calls,omitempty"`
+}
+
+// DecodeTrace labels every frame of the callTracer trace, see ChainGateway.DebugTraceTransaction. The code of each
+// callee is fetched once with the chain gateway and parsed to infer the types of the arguments of unresolved functions
+// and of the return values
+func (b BytecodeService) DecodeTrace(chainGateway external.ChainGateway, frame external.EthCallFrame) DecodedFrame {
+	parsers := make(map[string]asm.BytecodeParser)
+	return b.decodeFrame(frame, func(address string) asm.BytecodeParser {
+		address = strings.ToLower(address)
+		if parser, ok := parsers[address]; ok {
+			return parser
+		}
+		parser, err := fetchParser(chainGateway, address)
+		if err != nil {
+			b.logger.Debug("DecodeTrace: code not parsed", zap.String("address", address), zap.Error(err))
+		}
+		parsers[address] = parser
+		return parser
+	})
+}
+
+func (b BytecodeService) decodeFrame(frame external.EthCallFrame, parserAt func(address string) asm.BytecodeParser) DecodedFrame {
+	res := DecodedFrame{
+		Type:   frame.Type,
+		From:   frame.From,
+		To:     frame.To,
+		Value:  frame.Value,
+		Input:  frame.Input,
+		Output: frame.Output,
+		Error:  frame.Error,
+		Logs:   make([]DecodedTransactionLog, 0, len(frame.Logs)),
+		Calls:  make([]DecodedFrame, 0, len(frame.Calls)),
+	}
+	if frame.RevertReason != "" {
+		res.Error = fmt.Sprintf("%s: %s", frame.Error, frame.RevertReason)
+	}
+	input, err := hexutil.Decode(frame.Input)
+	if err == nil && len(input) >= 4 && frame.Type != "CREATE" && frame.Type != "CREATE2" {
+		b.labelFrame(&res, input, parserAt(frame.To))
+	}
+	for _, log := range frame.Logs {
+		decoded, err := b.signDecoder.DecodeEthLog(log)
+		if err != nil {
+			b.logger.Debug("DecodeTrace: log not decoded", zap.String("address", log.Address), zap.Error(err))
+		}
+		res.Logs = append(res.Logs, DecodedTransactionLog{EthLog: log, Decoded: decoded})
+	}
+	for _, call := range frame.Calls {
+		res.Calls = append(res.Calls, b.decodeFrame(call, parserAt))
+	}
+	return res
+}
+
+// labelFrame decodes the calldata and the return data of the frame, parser is nil when the callee has no code
+func (b BytecodeService) labelFrame(frame *DecodedFrame, input []byte, parser asm.BytecodeParser) {
+	selector := hexutil.Encode(input[:4])
+	var function asm.Function
+	if parser != nil {
+		function = parser.GetFunctions()[selector]
+	}
+	call, err := b.signDecoder.DecodeCalldata(input)
+	switch {
+	case err == nil:
+		frame.Function, frame.Verified, frame.Arguments = call.Signature.Sign, call.Signature.Verified, call.Arguments
+	case function.Sign != "":
+		frame.Function = fmt.Sprintf("%s(%s)", placeholderName(selector), strings.Join(function.Arguments, ","))
+		frame.Arguments, _ = decodeInferred(function.Arguments, input[4:])
+	default:
+		frame.Function = placeholderName(selector)
+	}
+	output, err := hexutil.Decode(frame.Output)
+	if err != nil || len(output) == 0 || frame.Error != "" || len(function.Outputs) == 0 {
+		return
+	}
+	frame.Outputs, err = decodeInferred(function.Outputs, output)
+	if err != nil {
+		b.logger.Debug("DecodeTrace: outputs not decoded", zap.String("sign", selector), zap.Error(err))
+	}
+}
+
+// decodeInferred decodes the data with the types inferred from the bytecode, the types are a guess and the data has
+// to be their canonical encoding
+func decodeInferred(types []string, data []byte) ([]DecodedValue, error) {
+	args, err := ToArguments(newInferredArguments(types))
+	if err != nil {
+		return nil, err
+	}
+	values, err := unpackStrict(args, data)
+	if err != nil {
+		return nil, err
+	}
+	return newDecodedValues(args, values), nil
+}
+
+// fetchParser fetches and parses the code deployed at the address, nil when there is no code
+func fetchParser(chainGateway external.ChainGateway, address string) (asm.BytecodeParser, error) {
+	resp, err := chainGateway.EthGetCode(address)
+	if err != nil {
+		return nil, err
+	}
+	code, err := hexutil.Decode(resp.Result)
+	if err != nil || len(code) == 0 {
+		return nil, err
+	}
+	d, err := asm.NewDisassembler(code)
+	if err != nil {
+		return nil, err
+	}
+	parser, _ := asm.NewBytecodeParser(d, asm.AutoDetect)
+	return parser, nil
+}
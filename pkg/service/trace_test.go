@@ -0,0 +1,107 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// traceFixture is a callTracer trace recorded by the go-ethereum tracer tests and the code of every callee
+type traceFixture struct {
+	Trace json.RawMessage   `json:"trace"`
+	Codes map[string]string `json:"codes"`
+}
+
+func TestBytecodeService_DecodeTrace(t *testing.T) {
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Function, "0x7065cb48", "addOwner(address)"},
+		{scraper.Function, "0x5dbe47e8", "contains(address)"},
+		{scraper.Function, "0x5c19a95c", "delegate(address)"},
+	})
+	b := NewBytecodeService(NewSignDecoder(external.NewSamczsunGateway(),
+		WithSourceChain(NewSourceChain(WithSource(NewSQLiteSource(db), 0)))))
+
+	type frame struct {
+		function string
+		outputs  []string
+		error    string
+	}
+	var flatten func(f DecodedFrame) []frame
+	flatten = func(f DecodedFrame) []frame {
+		res := frame{function: f.Function, outputs: make([]string, 0), error: f.Error}
+		for _, output := range f.Outputs {
+			res.outputs = append(res.outputs, output.Type+" "+output.String())
+		}
+		frames := []frame{res}
+		for _, call := range f.Calls {
+			frames = append(frames, flatten(call)...)
+		}
+		return frames
+	}
+	tests := []struct {
+		name    string
+		fixture string
+		want    []frame
+	}{
+		{
+			// Ropsten block 11495, the wallet calls contains on an owner set which delegates to a library
+			name:    "Nested calls and delegatecall",
+			fixture: "testdata/trace.json",
+			want: []frame{
+				{function: "addOwner(address)", outputs: []string{}},
+				// the output of contains is not inferred from the solc 0.4 owner set, unlike that of the library
+				{function: "contains(address)", outputs: []string{}},
+				{function: "unknown_7d65837a(uint256,address)", outputs: []string{"bool true"}},
+			},
+		},
+		{
+			// Goerli block 3212651, the revert data is not decoded as the outputs of the function
+			name:    "Reverted call",
+			fixture: "testdata/trace_revert.json",
+			want:    []frame{{function: "delegate(address)", outputs: []string{}, error: "execution reverted"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := os.ReadFile(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fixture traceFixture
+			if err = json.Unmarshal(raw, &fixture); err != nil {
+				t.Fatal(err)
+			}
+			results := map[string]string{"debug_traceTransaction": string(fixture.Trace)}
+			for address, code := range fixture.Codes {
+				results["eth_getCode "+address] = `"` + code + `"`
+			}
+			var requests []external.EthReq
+			server := newTestNode(t, results, &requests)
+			g := external.NewChainGatewayWithOpts(external.WithEthEndpoint(server.URL))
+			trace, err := g.DebugTraceTransaction(testTxHash)
+			if err != nil || trace.Result == nil {
+				t.Fatalf("DebugTraceTransaction() got = %+v, err = %v", trace, err)
+			}
+
+			got := b.DecodeTrace(g, *trace.Result)
+			if gotFrames := flatten(got); !reflect.DeepEqual(gotFrames, tt.want) {
+				t.Errorf("DecodeTrace() got = %+v, want %+v", gotFrames, tt.want)
+			}
+
+			codeRequests := make(map[string]int)
+			for _, req := range requests {
+				if req.Method == "eth_getCode" {
+					codeRequests[req.Params[0].(string)]++
+				}
+			}
+			for address := range fixture.Codes {
+				if codeRequests[address] != 1 {
+					t.Errorf("DecodeTrace() fetched the code of %s %d times", address, codeRequests[address])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+// DecodedTransaction is a transaction with its calldata and the logs of its receipt decoded as far as possible
+type DecodedTransaction struct {
+	external.EthTransaction
+	// Status is 0x1 on success and 0x0 on failure
+	Status string
+	// Call is nil for a contract creation, a plain transfer or calldata which could not be decoded
+	Call *DecodedCall
+	Logs []DecodedTransactionLog
+}
+
+// DecodedTransactionLog Decoded is nil when the log could not be decoded
+type DecodedTransactionLog struct {
+	external.EthLog
+	Decoded *DecodedLog `json:"decoded,omitempty"`
+}
+
+// DecodeTransaction decodes the calldata of the transaction and every log of its receipt, see DecodeCalldata and
+// DecodeEthLog. Parts which cannot be decoded are kept raw
+func (s SignDecoderService) DecodeTransaction(tx external.EthTransaction, receipt external.EthReceipt) DecodedTransaction {
+	res := DecodedTransaction{
+		EthTransaction: tx,
+		Status:         receipt.Status,
+		Logs:           make([]DecodedTransactionLog, 0, len(receipt.Logs)),
+	}
+	input, err := hexutil.Decode(tx.Input)
+	if tx.To != "" && err == nil && len(input) > 0 {
+		res.Call, err = s.DecodeCalldata(input)
+		if err != nil {
+			s.logger.Debug("DecodeTransaction: calldata not decoded", zap.String("hash", tx.Hash), zap.Error(err))
+		}
+	}
+	for _, log := range receipt.Logs {
+		decoded, err := s.DecodeEthLog(log)
+		if err != nil {
+			s.logger.Debug("DecodeTransaction: log not decoded", zap.String("hash", tx.Hash),
+				zap.String("address", log.Address), zap.Error(err))
+		}
+		res.Logs = append(res.Logs, DecodedTransactionLog{EthLog: log, Decoded: decoded})
+	}
+	return res
+}
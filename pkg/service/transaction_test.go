@@ -0,0 +1,94 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/arhamj/abi-extractor/pkg/external"
+	"github.com/arhamj/abi-extractor/pkg/scraper"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+const (
+	testTxHash = "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"
+	testTx     = `{"hash":"` + testTxHash + `","blockNumber":"0x10","from":"0x1111111111111111111111111111111111111111",` +
+		`"to":"0x2222222222222222222222222222222222222222","value":"0x0","input":"0xa9059cbb` +
+		`0000000000000000000000003333333333333333333333333333333333333333` +
+		`00000000000000000000000000000000000000000000000000000000000003e8"}`
+	testReceipt = `{"transactionHash":"` + testTxHash + `","status":"0x1","contractAddress":null,"logs":[` +
+		`{"address":"0x2222222222222222222222222222222222222222","topics":[` +
+		`"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",` +
+		`"0x0000000000000000000000001111111111111111111111111111111111111111",` +
+		`"0x0000000000000000000000003333333333333333333333333333333333333333"],` +
+		`"data":"0x00000000000000000000000000000000000000000000000000000000000003e8"},` +
+		`{"address":"0x2222222222222222222222222222222222222222","topics":[` +
+		`"0x0000000000000000000000000000000000000000000000000000000000000001"],"data":"0x"}]}`
+)
+
+// newTestNode returns a JSON-RPC server answering with the raw JSON result of each method, results keyed by the method
+// followed by the first parameter, for e.g. "eth_getCode 0x1111111111111111111111111111111111111111", take precedence.
+// Every request is recorded in requests when it is not nil
+func newTestNode(t *testing.T, results map[string]string, requests *[]external.EthReq) *httptest.Server {
+	var lock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req external.EthReq
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid JSON-RPC request: %v", err)
+		}
+		if requests != nil {
+			lock.Lock()
+			*requests = append(*requests, req)
+			lock.Unlock()
+		}
+		result, ok := results[req.Method]
+		if len(req.Params) > 0 {
+			if byParam, found := results[fmt.Sprintf("%s %v", req.Method, req.Params[0])]; found {
+				result, ok = byParam, true
+			}
+		}
+		if !ok {
+			result = "null"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSignDecoderService_DecodeTransaction(t *testing.T) {
+	server := newTestNode(t, map[string]string{
+		"eth_getTransactionByHash":  testTx,
+		"eth_getTransactionReceipt": testReceipt,
+	}, nil)
+	g := external.NewChainGatewayWithOpts(external.WithEthEndpoint(server.URL))
+	tx, err := g.EthGetTransactionByHash(testTxHash)
+	if err != nil || tx.Result == nil {
+		t.Fatalf("EthGetTransactionByHash() got = %+v, err = %v", tx, err)
+	}
+	receipt, err := g.EthGetTransactionReceipt(testTxHash)
+	if err != nil || receipt.Result == nil {
+		t.Fatalf("EthGetTransactionReceipt() got = %+v, err = %v", receipt, err)
+	}
+	db := NewTestScraperDb(t, []testSignMapping{
+		{scraper.Function, "0xa9059cbb", "transfer(address,uint256)"},
+		{scraper.Event, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", "Transfer(address,address,uint256)"},
+	})
+	s := NewSignDecoder(external.NewSamczsunGateway(),
+		WithSourceChain(NewSourceChain(WithSource(NewSQLiteSource(db), 0))))
+	got := s.DecodeTransaction(*tx.Result, *receipt.Result)
+	if got.Status != "0x1" || got.Call == nil || got.Call.Signature.Sign != "transfer(address,uint256)" {
+		t.Errorf("DecodeTransaction() call = %+v, status = %v", got.Call, got.Status)
+	}
+	if len(got.Logs) != 2 {
+		t.Fatalf("DecodeTransaction() logs = %+v", got.Logs)
+	}
+	if got.Logs[0].Decoded == nil || got.Logs[0].Decoded.Signature.Sign != "Transfer(address,address,uint256)" {
+		t.Errorf("DecodeTransaction() log 0 = %+v", got.Logs[0].Decoded)
+	}
+	if got.Logs[1].Decoded != nil || got.Logs[1].Address != "0x2222222222222222222222222222222222222222" {
+		t.Errorf("DecodeTransaction() log 1 = %+v", got.Logs[1])
+	}
+}
@@ -11,8 +11,8 @@ func NewSQLiteDB(dbFilePath string, migrations ...string) (*sql.DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(migrations) > 0 {
-		_, err = db.Exec(migrations[0])
+	for _, migration := range migrations {
+		_, err = db.Exec(migration)
 		if err != nil {
 			return nil, err
 		}